@@ -0,0 +1,231 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/swaggest/refl"
+)
+
+// cachedDefinition is a processed definition schema with references to other definitions it depends on.
+type cachedDefinition struct {
+	schema Schema
+	ref    Ref
+	deps   []refl.TypeString
+	key    string
+}
+
+// cacheKey describes reflect options that affect definitions, cached definitions are only used with same options.
+//
+// Dialect options are not a part of key, because dialect conversion is applied after reflection.
+func (rc *ReflectContext) cacheKey() string {
+	return fmt.Sprintf("%s|%s|%v|%t|%t|%t|%t|%t|%t|%t|%s|%t|%t|%t|%t|%t|%t|%t|%t|%t|%v|%t|%t|%t",
+		rc.DefinitionsPrefix, rc.PropertyNameTag, rc.PropertyNameAdditionalTags, rc.ProcessWithoutTags,
+		rc.UnnamedFieldWithTag, rc.EnvelopNullability, rc.SkipEmbeddedMapsSlices, rc.ExclusiveBoundsDraft04,
+		rc.StrictTags, rc.BigNumbers, rc.DeprecatedReasonProperty, rc.InferJSONMarshalers,
+		rc.ForbidUnevaluatedProperties, rc.SkipNonConstraints, rc.SkipUnsupportedProperties, rc.RootRef,
+		rc.SkipElementSamples, rc.DurationAsString, rc.BigTypesAsStrings, rc.DecimalAsNumber,
+		rc.JSONNumberTypes, rc.ReaderAsBinary, rc.InferNullableWrappers, rc.VerifyExamples)
+}
+
+// hasFuncOptions checks if reflect options with functions or mappings are used, such options can not be
+// compared between Reflect calls, so definitions cache is bypassed.
+func (rc *ReflectContext) hasFuncOptions() bool {
+	return rc.interceptors || rc.InterceptNullability != nil || rc.DefName != nil ||
+		len(rc.PropertyNameMapping) > 0 || len(rc.TagMapping) > 0
+}
+
+// cachedDefinition replaces schema with a copy of cached definition and registers cached definitions it
+// depends on, it returns false if definition is not available in cache.
+//
+// Definitions reflected from non-zero values (other than pointers to zero values) are not cached,
+// because value may affect schema.
+func (r *Reflector) cachedDefinition(rc *ReflectContext, typeString refl.TypeString, defName string,
+	v reflect.Value, schema *Schema,
+) bool {
+	if !r.CacheDefinitions || rc.hasFuncOptions() {
+		return false
+	}
+
+	for v.IsValid() && v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if !v.IsValid() || !v.IsZero() {
+		if rc.uncacheable == nil {
+			rc.uncacheable = map[refl.TypeString]bool{}
+		}
+
+		rc.uncacheable[typeString] = true
+
+		return false
+	}
+
+	if len(rc.Path) <= 1 || rc.typeCycles[typeString] != nil {
+		return false
+	}
+
+	if _, ok := rc.definitionRefs[typeString]; ok {
+		return false
+	}
+
+	found := map[refl.TypeString]cachedDefinition{}
+
+	r.mu.Lock()
+	ok := r.collectCached(rc, rc.cacheKey(), typeString, defName, found)
+	r.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	for ts, cd := range found {
+		if ts == typeString {
+			continue
+		}
+
+		if rc.definitions == nil {
+			rc.definitions = make(map[refl.TypeString]*Schema, len(found))
+			rc.definitionRefs = make(map[refl.TypeString]Ref, len(found))
+		}
+
+		def := cd.schema.Clone()
+		rc.definitions[ts] = &def
+		rc.definitionRefs[ts] = cd.ref
+	}
+
+	parent := schema.Parent
+	*schema = found[typeString].schema.Clone()
+	schema.Parent = parent
+
+	return true
+}
+
+func (r *Reflector) collectCached(rc *ReflectContext, key string, typeString refl.TypeString, defName string,
+	found map[refl.TypeString]cachedDefinition,
+) bool {
+	if _, ok := found[typeString]; ok {
+		return true
+	}
+
+	if defName == "" {
+		if _, ok := rc.definitionRefs[typeString]; ok || rc.typeCycles[typeString] != nil {
+			return true
+		}
+	}
+
+	cd, ok := r.defCache[typeString]
+	if !ok || cd.key != key {
+		return false
+	}
+
+	if (defName != "" && cd.ref.Name != defName) || (!rc.RootRef && cd.ref.Name == rc.rootDefName) {
+		return false
+	}
+
+	found[typeString] = cd
+
+	for _, dep := range cd.deps {
+		if !r.collectCached(rc, key, dep, "", found) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cacheDefinitions stores definitions of successful reflection in cache.
+func (r *Reflector) cacheDefinitions(rc *ReflectContext) {
+	if !r.CacheDefinitions || len(rc.definitions) == 0 || rc.hasFuncOptions() {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.defCache == nil {
+		r.defCache = make(map[refl.TypeString]cachedDefinition, len(rc.definitions))
+	}
+
+	key := rc.cacheKey()
+	byRef := make(map[string]refl.TypeString, len(rc.definitionRefs))
+
+	for ts, ref := range rc.definitionRefs {
+		byRef[*ref.Schema().Ref] = ts
+	}
+
+	for ts, def := range rc.definitions {
+		if rc.uncacheable[ts] {
+			continue
+		}
+
+		refs := map[string]bool{}
+		collectRefs(def, refs)
+
+		cd := cachedDefinition{
+			schema: def.Clone(),
+			ref:    rc.definitionRefs[ts],
+			key:    key,
+		}
+
+		cacheable := true
+
+		for ref := range refs {
+			dep, ok := byRef[ref]
+			if !ok {
+				// Reference is not to a definition (e.g. root "#"), such schema depends on reflection context.
+				cacheable = false
+
+				break
+			}
+
+			if dep != ts {
+				cd.deps = append(cd.deps, dep)
+			}
+		}
+
+		if !cacheable {
+			continue
+		}
+
+		r.defCache[ts] = cd
+	}
+}
+
+// collectRefs adds references of schema and its subschemas to refs.
+func collectRefs(s *Schema, refs map[string]bool) {
+	if s.Ref != nil {
+		refs[*s.Ref] = true
+	}
+
+	s.eachSubSchema(func(_ string, sub *Schema) {
+		collectRefs(sub, refs)
+	})
+
+	visit := func(sb SchemaOrBool) {
+		if sb.TypeObject != nil {
+			collectRefs(sb.TypeObject, refs)
+		}
+	}
+
+	for _, v := range s.ExtraProperties {
+		switch sv := v.(type) {
+		case Schema:
+			collectRefs(&sv, refs)
+		case *Schema:
+			if sv != nil {
+				collectRefs(sv, refs)
+			}
+		case SchemaOrBool:
+			visit(sv)
+		case []SchemaOrBool:
+			for _, sb := range sv {
+				visit(sb)
+			}
+		case map[string]SchemaOrBool:
+			for _, sb := range sv {
+				visit(sb)
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestReflector_CacheDefinitions(t *testing.T) {
+	type Tag struct {
+		Name string `json:"name" minLength:"1"`
+	}
+
+	type Item struct {
+		Name string `json:"name" required:"true"`
+		Tags []Tag  `json:"tags"`
+	}
+
+	type Order struct {
+		Items []Item `json:"items"`
+		Main  *Item  `json:"main"`
+	}
+
+	type Invoice struct {
+		Order Order  `json:"order"`
+		Item  Item   `json:"item"`
+		Note  string `json:"note"`
+	}
+
+	prepared := 0
+
+	cached := jsonschema.Reflector{CacheDefinitions: true}
+	cached.AddPreparer(Item{}, func(_ *jsonschema.Schema) error {
+		prepared++
+
+		return nil
+	})
+
+	fresh := jsonschema.Reflector{}
+
+	for i, sample := range []interface{}{Order{}, Invoice{}, Order{}, Item{}} {
+		expected, err := fresh.Reflect(sample)
+		require.NoError(t, err)
+
+		s, err := cached.Reflect(sample)
+		require.NoError(t, err)
+
+		assert.Equal(t, marshal(t, expected), marshal(t, s), i)
+	}
+
+	// Item is prepared for first Order and for Item reflected as root.
+	assert.Equal(t, 2, prepared)
+
+	type Cart struct {
+		Main *Item `json:"main"`
+	}
+
+	// Cached definitions are not used for non-zero values.
+	_, err := cached.Reflect(Cart{Main: &Item{Name: "foo"}})
+	require.NoError(t, err)
+	assert.Equal(t, 3, prepared)
+
+	_, err = cached.Reflect(Cart{Main: &Item{}})
+	require.NoError(t, err)
+	assert.Equal(t, 3, prepared)
+
+	// Definitions are not reused with different options.
+	s, err := cached.Reflect(Order{}, jsonschema.DefinitionsPrefix("#/components/schemas/"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, prepared)
+	assert.Equal(t, "#/components/schemas/JsonschemaGoTestItem", *s.Properties["main"].TypeObject.Ref)
+
+	// Definitions are not used or cached with function options.
+	intercepted := 0
+	intercept := jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		if params.Processed && params.Name == "tags" {
+			intercepted++
+		}
+
+		return nil
+	})
+
+	_, err = cached.Reflect(Order{}, intercept)
+	require.NoError(t, err)
+	assert.Equal(t, 5, prepared)
+	assert.Equal(t, 1, intercepted)
+
+	_, err = cached.Reflect(Order{}, intercept)
+	require.NoError(t, err)
+	assert.Equal(t, 6, prepared)
+	assert.Equal(t, 2, intercepted)
+
+	_, err = cached.Reflect(Order{}, jsonschema.TagMapping(map[string]string{"minLength": "min"}))
+	require.NoError(t, err)
+	assert.Equal(t, 7, prepared)
+
+	// Configuration changes reset cache.
+	cached.AddTypeMapping(Tag{}, "")
+
+	expected, err := fresh.Reflect(Invoice{})
+	require.NoError(t, err)
+
+	s, err = cached.Reflect(Invoice{})
+	require.NoError(t, err)
+	assert.Equal(t, 8, prepared)
+	assert.NotEqual(t, marshal(t, expected), marshal(t, s))
+	assert.Contains(t, marshal(t, s), `"tags":{"items":{"type":"string"}`)
+}
+
+func TestReflector_CacheDefinitions_verifyExamples(t *testing.T) {
+	type Sub struct {
+		A int `json:"a" maximum:"3" default:"5"`
+	}
+
+	type Root1 struct {
+		S Sub `json:"s"`
+	}
+
+	type Root2 struct {
+		S Sub `json:"s"`
+	}
+
+	r := jsonschema.Reflector{CacheDefinitions: true}
+
+	_, err := r.Reflect(Root1{})
+	require.NoError(t, err)
+
+	_, err = r.Reflect(Root2{}, jsonschema.VerifyExamples)
+	assert.EqualError(t, err, "s.A: invalid default: #: 5 is greater than 3")
+}
+
+func marshal(t *testing.T, v interface{}) string {
+	t.Helper()
+
+	j, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return string(j)
+}
@@ -0,0 +1,98 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// fieldCondition is a parsed `when:"property=value"` field tag.
+type fieldCondition struct {
+	path     string
+	property string
+	value    string
+	required string
+}
+
+func parseWhenTag(path, propName, when string) (fieldCondition, error) {
+	property, value, found := strings.Cut(when, "=")
+	if !found || property == "" {
+		return fieldCondition{}, fmt.Errorf("%s: malformed when tag %q, property=value expected", path, when)
+	}
+
+	return fieldCondition{path: path, property: property, value: value, required: propName}, nil
+}
+
+// applyConditions adds `if`/`then` chains that require fields with `when` tag if discriminator property has
+// the value.
+//
+// Conditions on the same property are chained with `else`, the first chain is added to parent schema
+// if it does not have `if` yet, other chains are added to `allOf`.
+func applyConditions(parent *Schema, conditions []fieldCondition) error {
+	var (
+		properties []string
+		chains     = map[string][]*Schema{}
+		blocks     = map[[2]string]*Schema{}
+	)
+
+	for _, c := range conditions {
+		ps, found := parent.Properties[c.property]
+		if !found || ps.TypeObject == nil {
+			return fmt.Errorf("%s: unknown when property %s", c.path, c.property)
+		}
+
+		key := [2]string{c.property, c.value}
+
+		if block, found := blocks[key]; found {
+			block.Then.TypeObject.Required = append(block.Then.TypeObject.Required, c.required)
+
+			continue
+		}
+
+		cond := Schema{}
+		cond.WithConst(conditionValue(*ps.TypeObject, c.value))
+
+		block := &Schema{}
+		block.WithIf((&Schema{}).WithProperties(map[string]SchemaOrBool{c.property: cond.ToSchemaOrBool()}).
+			WithRequired(c.property).ToSchemaOrBool())
+		block.WithThen((&Schema{}).WithRequired(c.required).ToSchemaOrBool())
+
+		if _, found := chains[c.property]; !found {
+			properties = append(properties, c.property)
+		}
+
+		blocks[key] = block
+		chains[c.property] = append(chains[c.property], block)
+	}
+
+	for _, property := range properties {
+		chain := chains[property]
+
+		for i := len(chain) - 1; i > 0; i-- {
+			chain[i-1].WithElse(chain[i].ToSchemaOrBool())
+		}
+
+		head := chain[0]
+
+		if parent.If == nil {
+			parent.If, parent.Then, parent.Else = head.If, head.Then, head.Else
+		} else {
+			parent.AllOf = append(parent.AllOf, head.ToSchemaOrBool())
+		}
+	}
+
+	return nil
+}
+
+// conditionValue decodes tag value according to type of discriminator property.
+func conditionValue(ps Schema, value string) interface{} {
+	if ps.HasType(Integer) || ps.HasType(Number) || ps.HasType(Boolean) {
+		var v interface{}
+
+		if err := json.Unmarshal([]byte(value), &v); err == nil {
+			return v
+		}
+	}
+
+	return value
+}
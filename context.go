@@ -3,6 +3,8 @@ package jsonschema
 import (
 	"context"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/swaggest/refl"
@@ -15,6 +17,15 @@ func CollectDefinitions(f func(name string, schema Schema)) func(*ReflectContext
 	}
 }
 
+// CollectDefinitionsTo enables putting definitions to provided sink instead of result schema.
+//
+// Definitions are put in alphabetical order of names, first error returned by sink fails reflection.
+func CollectDefinitionsTo(sink DefinitionsSink) func(*ReflectContext) {
+	return func(rc *ReflectContext) {
+		rc.DefinitionsSink = sink
+	}
+}
+
 // DefinitionsPrefix sets up location for newly created references, default "#/definitions/".
 func DefinitionsPrefix(prefix string) func(*ReflectContext) {
 	return func(rc *ReflectContext) {
@@ -123,6 +134,8 @@ func InterceptType(f InterceptTypeFunc) func(*ReflectContext) {
 // InterceptSchema adds hook to customize schema.
 func InterceptSchema(f InterceptSchemaFunc) func(*ReflectContext) {
 	return func(rc *ReflectContext) {
+		rc.interceptors = true
+
 		if rc.interceptSchema != nil {
 			prev := rc.interceptSchema
 			rc.interceptSchema = func(params InterceptSchemaParams) (b bool, err error) {
@@ -155,6 +168,8 @@ func InterceptProperty(f InterceptPropertyFunc) func(*ReflectContext) {
 // InterceptProp adds a hook to customize property schema.
 func InterceptProp(f InterceptPropFunc) func(reflectContext *ReflectContext) {
 	return func(rc *ReflectContext) {
+		rc.interceptors = true
+
 		if rc.interceptProp != nil {
 			prev := rc.interceptProp
 			rc.interceptProp = func(params InterceptPropParams) error {
@@ -228,6 +243,43 @@ func PropertyNameMapping(mapping map[string]string) func(rc *ReflectContext) {
 	}
 }
 
+// TagMapping enables reading schema keywords from custom field tags, e.g. "minimum":"min".
+func TagMapping(mapping map[string]string) func(rc *ReflectContext) {
+	return func(rc *ReflectContext) {
+		rc.TagMapping = mapping
+	}
+}
+
+// ExclusiveBoundsDraft04 enables draft-04 style of boolean `exclusiveMinimum` and `exclusiveMaximum`.
+//
+// Exclusive bound value is moved to `minimum`/`maximum` and boolean flag is added, e.g.
+// `{"minimum":5,"exclusiveMinimum":true}`, this style is used by Swagger 2.0 and OpenAPI 3.0.
+func ExclusiveBoundsDraft04(rc *ReflectContext) {
+	rc.ExclusiveBoundsDraft04 = true
+}
+
+// ForbidUnevaluatedProperties enables `unevaluatedProperties: false` for structures that have embedded references
+// in `allOf` (see EmbedReferencer).
+//
+// Unlike `additionalProperties: false`, it does not reject properties defined in `allOf` items, so strictness works
+// with references in draft 2019-09 and newer. Conflicting `additionalProperties: false` is removed.
+func ForbidUnevaluatedProperties(rc *ReflectContext) {
+	rc.ForbidUnevaluatedProperties = true
+}
+
+// BigNumbers enables precise numeric tag values that can not be represented with float64.
+func BigNumbers(rc *ReflectContext) {
+	rc.BigNumbers = true
+}
+
+// DeprecatedReasonProperty sets up name of JSON property to store `deprecatedMsg` tag value,
+// default "x-deprecated-reason".
+func DeprecatedReasonProperty(name string) func(rc *ReflectContext) {
+	return func(rc *ReflectContext) {
+		rc.DeprecatedReasonProperty = name
+	}
+}
+
 // ProcessWithoutTags enables processing fields without any tags specified.
 func ProcessWithoutTags(rc *ReflectContext) {
 	rc.ProcessWithoutTags = true
@@ -243,6 +295,67 @@ func SkipUnsupportedProperties(rc *ReflectContext) {
 	rc.SkipUnsupportedProperties = true
 }
 
+// SkipElementSamples disables using first element of populated slices and maps as sample values for items.
+//
+// Items are reflected from zero values of element type, so schema only depends on types and reflection
+// does not depend on size or content of collections.
+func SkipElementSamples(rc *ReflectContext) {
+	rc.SkipElementSamples = true
+}
+
+// DurationAsString enables reflecting time.Duration as `{"type":"string","format":"duration"}` with
+// an example in time.ParseDuration format (e.g. "1h30m") instead of an integer number of nanoseconds.
+//
+// This matches APIs that serialize durations with custom marshalers using time.Duration.String.
+func DurationAsString(rc *ReflectContext) {
+	rc.DurationAsString = true
+}
+
+// BigTypesAsStrings enables reflecting big.Int as a string with a numeric pattern.
+//
+// By default big.Int is reflected as `integer`. big.Float and big.Rat are always reflected as strings
+// with numeric and fraction (e.g. "1/3") patterns, as they are marshaled as text.
+func BigTypesAsStrings(rc *ReflectContext) {
+	rc.BigTypesAsStrings = true
+}
+
+// DecimalAsNumber enables reflecting well-known decimal types as `number` instead of a string.
+//
+// By default github.com/shopspring/decimal and github.com/cockroachdb/apd decimals are reflected as
+// `{"type":"string","format":"decimal","pattern":...}`, as they are marshaled to JSON strings to keep precision.
+func DecimalAsNumber(rc *ReflectContext) {
+	rc.DecimalAsNumber = true
+}
+
+// JSONNumberAs sets up types of json.Number, default `number`.
+//
+// For example, JSONNumberAs(jsonschema.Number, jsonschema.String) allows both numbers and numeric strings
+// for fields that are decoded with json.Decoder.UseNumber or `json:",string"` tag.
+func JSONNumberAs(types ...SimpleType) func(rc *ReflectContext) {
+	return func(rc *ReflectContext) {
+		rc.JSONNumberTypes = types
+	}
+}
+
+// ReaderAsBinary enables reflecting io.Reader and other interfaces that embed it (e.g. io.ReadCloser)
+// as `{"type":"string","format":"binary"}`, like uploaded files.
+//
+// Uploaded files of *multipart.FileHeader and multipart.File are reflected as binary strings regardless of this option.
+func ReaderAsBinary(rc *ReflectContext) {
+	rc.ReaderAsBinary = true
+}
+
+// InferNullableWrappers enables reflecting nullable wrappers as nullable values.
+//
+// Nullable wrapper is a struct that implements json.Marshaler and has `Valid bool` and value fields,
+// for example `struct{ sql.NullString }` with MarshalJSON that produces string or null.
+// Such struct is reflected as schema of its value with `null` type added instead of an object with `Valid` property.
+//
+// Types of gopkg.in/guregu/null (and github.com/guregu/null) are recognized regardless of this option.
+func InferNullableWrappers(rc *ReflectContext) {
+	rc.InferNullableWrappers = true
+}
+
 // ReflectContext accompanies single reflect operation.
 type ReflectContext struct {
 	// Context allows communicating user data between reflection steps.
@@ -255,6 +368,10 @@ type ReflectContext struct {
 	// Non-empty CollectDefinitions disables collection of definitions into resulting schema.
 	CollectDefinitions func(name string, schema Schema)
 
+	// DefinitionsSink receives named schemas after reflection, can be nil.
+	// Non-empty DefinitionsSink disables collection of definitions into resulting schema.
+	DefinitionsSink DefinitionsSink
+
 	// DefinitionsPrefix defines location of named schemas, default #/definitions/.
 	DefinitionsPrefix string
 
@@ -269,6 +386,10 @@ type ReflectContext struct {
 	// Only applicable to top-level properties (including embedded).
 	PropertyNameMapping map[string]string
 
+	// TagMapping enables reading schema keywords from custom field tags, e.g. "minimum":"min".
+	// Keyword tag takes precedence if both keyword and custom tags are present.
+	TagMapping map[string]string
+
 	// ProcessWithoutTags enables processing fields without any tags specified.
 	ProcessWithoutTags bool
 
@@ -310,17 +431,96 @@ type ReflectContext struct {
 	interceptProp        InterceptPropFunc
 	InterceptNullability InterceptNullabilityFunc
 
+	// ExclusiveBoundsDraft04 enables draft-04 style of boolean `exclusiveMinimum` and `exclusiveMaximum`
+	// along with `minimum` and `maximum` values.
+	ExclusiveBoundsDraft04 bool
+
+	// StrictTags enables validation of `format` tag values and applicability of constraint tags to property type.
+	StrictTags bool
+
+	// BigNumbers enables storing numeric tag values as json.Number if they can not be represented with float64,
+	// e.g. `maximum:"9223372036854775807"`.
+	// Such values of `minimum`, `maximum`, `exclusiveMinimum`, `exclusiveMaximum` and `multipleOf` are also
+	// stored in Schema.ExtraProperties, typed fields keep nearest float64 value and JSON encoding uses the precise one.
+	BigNumbers bool
+
+	// DeprecatedReasonProperty is a name of JSON property to store `deprecatedMsg` tag value,
+	// default XDeprecatedReason.
+	DeprecatedReasonProperty string
+
+	// InferJSONMarshalers enables schema inference from JSON output of json.Marshaler implementations.
+	InferJSONMarshalers bool
+
+	// VerifyExamples enables validation of `default` and `examples` values of properties against property schemas.
+	VerifyExamples bool
+
+	// StrictKeywords enables removal of non-standard keywords from reflected schema, see KeywordsEnvelope.
+	StrictKeywords bool
+
+	// KeywordsEnvelope is a name of keyword to store non-standard keywords in StrictKeywords mode,
+	// non-standard keywords are removed if empty.
+	KeywordsEnvelope string
+
+	// SelfValidate enables validation of reflected schema against the meta-schema of reflected dialect.
+	SelfValidate bool
+
+	// ForbidUnevaluatedProperties enables `unevaluatedProperties: false` for structures with
+	// embedded references in `allOf`.
+	ForbidUnevaluatedProperties bool
+
+	// Draft201909 enables conversion of reflected schema to JSON Schema draft 2019-09 dialect.
+	Draft201909 bool
+
+	// Draft202012 enables conversion of reflected schema to JSON Schema draft 2020-12 dialect.
+	Draft202012 bool
+
+	// OpenAPI30 enables conversion of reflected schema to OpenAPI 3.0 schema dialect.
+	OpenAPI30 bool
+
+	// Swagger20 enables conversion of reflected schema to Swagger 2.0 schema dialect.
+	Swagger20 bool
+
+	// CollectWarnings is triggered for lossy changes made by dialect conversion, can be nil.
+	CollectWarnings func(w Warning)
+
 	// SkipNonConstraints disables parsing of `default` and `example` field tags.
 	SkipNonConstraints bool
 
 	// SkipUnsupportedProperties skips properties with unsupported types (func, chan, etc...) instead of failing.
 	SkipUnsupportedProperties bool
 
+	// SkipElementSamples disables using first element of populated slice or map as a sample value for items.
+	SkipElementSamples bool
+
+	// DurationAsString enables reflecting time.Duration as a string in time.ParseDuration format.
+	DurationAsString bool
+
+	// BigTypesAsStrings enables reflecting big.Int as a string with a pattern instead of an integer.
+	BigTypesAsStrings bool
+
+	// DecimalAsNumber enables reflecting decimal types (shopspring/decimal, cockroachdb/apd) as numbers.
+	DecimalAsNumber bool
+
+	// JSONNumberTypes defines types of json.Number, default is `number`.
+	JSONNumberTypes []SimpleType
+
+	// ReaderAsBinary enables reflecting io.Reader interfaces as binary strings.
+	ReaderAsBinary bool
+
+	// InferNullableWrappers enables reflecting structs with Valid and value fields and JSON marshaler as nullable values.
+	InferNullableWrappers bool
+
 	Path           []string
 	definitions    map[refl.TypeString]*Schema // list of all definition objects
 	definitionRefs map[refl.TypeString]Ref
 	typeCycles     map[refl.TypeString]*Schema
 	rootDefName    string
+	inlineNext     bool   // inlineNext disables referencing for the next reflected schema.
+	defNameNext    string // defNameNext overrides definition name for the next reflected schema.
+	valueChecks    []valueCheck
+	uncacheable    map[refl.TypeString]bool // uncacheable lists definitions reflected from non-zero values.
+	interceptors   bool                     // interceptors is set when InterceptSchema or InterceptProp is used.
+	inlining       map[refl.TypeString]bool // inlining lists types that are being reflected inline.
 }
 
 func (rc *ReflectContext) getDefinition(ref string) *Schema {
@@ -333,6 +533,125 @@ func (rc *ReflectContext) getDefinition(ref string) *Schema {
 	return &Schema{}
 }
 
+// pruneUnreachableDefinitions removes definitions that are not referenced from root schema directly or
+// through other definitions, e.g. definitions of intermediate types that were replaced by interceptors.
+func (rc *ReflectContext) pruneUnreachableDefinitions(root *Schema) {
+	if len(rc.definitions) == 0 {
+		return
+	}
+
+	byRef := make(map[string]refl.TypeString, len(rc.definitionRefs))
+
+	for ts, ref := range rc.definitionRefs {
+		byRef[ref.Path+ref.Name] = ts
+	}
+
+	reached := make(map[refl.TypeString]bool, len(rc.definitions))
+
+	var visit func(s *Schema)
+
+	visit = func(s *Schema) {
+		refs := map[string]bool{}
+		collectRefs(s, refs)
+
+		for ref := range refs {
+			ts, ok := byRef[ref]
+			if !ok || reached[ts] {
+				continue
+			}
+
+			reached[ts] = true
+
+			if def := rc.definitions[ts]; def != nil {
+				visit(def)
+			}
+		}
+	}
+
+	visit(root)
+
+	for ts := range rc.definitions {
+		if !reached[ts] {
+			delete(rc.definitions, ts)
+		}
+	}
+}
+
+// sortedDefinitions returns type strings of definitions ordered by definition names.
+func (rc *ReflectContext) sortedDefinitions() []refl.TypeString {
+	res := make([]refl.TypeString, 0, len(rc.definitions))
+
+	for ts := range rc.definitions {
+		res = append(res, ts)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return rc.definitionRefs[res[i]].Name < rc.definitionRefs[res[j]].Name
+	})
+
+	return res
+}
+
+// convertDialect converts reflected schema to enabled output dialect, path is a location of schema.
+func (rc *ReflectContext) convertDialect(path string, s *Schema) {
+	switch {
+	case rc.Draft202012:
+		s.ConvertDraft202012()
+	case rc.Draft201909:
+		s.ConvertDraft201909()
+	case rc.OpenAPI30:
+		rc.collectWarnings(convertOpenAPI30(path, s))
+	case rc.Swagger20:
+		rc.collectWarnings(convertSwagger20(path, s))
+	}
+}
+
+func (rc *ReflectContext) collectWarnings(warnings []Warning) {
+	if rc.CollectWarnings == nil {
+		return
+	}
+
+	for _, w := range warnings {
+		rc.CollectWarnings(w)
+	}
+}
+
+func (rc *ReflectContext) deprecatedReasonProperty() string {
+	if rc.DeprecatedReasonProperty != "" {
+		return rc.DeprecatedReasonProperty
+	}
+
+	return XDeprecatedReason
+}
+
+// mapTag adds keyword tags from their custom aliases defined in TagMapping.
+func (rc *ReflectContext) mapTag(tag reflect.StructTag) reflect.StructTag {
+	if len(rc.TagMapping) == 0 {
+		return tag
+	}
+
+	keywords := make([]string, 0, len(rc.TagMapping))
+	for keyword := range rc.TagMapping {
+		keywords = append(keywords, keyword)
+	}
+
+	sort.Strings(keywords)
+
+	res := string(tag)
+
+	for _, keyword := range keywords {
+		if _, found := tag.Lookup(keyword); found {
+			continue
+		}
+
+		if value, found := tag.Lookup(rc.TagMapping[keyword]); found {
+			res += " " + keyword + ":" + strconv.Quote(value)
+		}
+	}
+
+	return reflect.StructTag(strings.TrimPrefix(res, " "))
+}
+
 func (rc *ReflectContext) deprecatedFallback() {
 	if rc.InterceptType != nil {
 		f := rc.InterceptType
@@ -0,0 +1,41 @@
+package jsonschema
+
+// ConvertDraft07To201909 returns a copy of draft-07 schema converted to draft 2019-09 dialect.
+//
+// It can be used with schemas that are reflected or unmarshaled from files, see Schema.ConvertDraft201909.
+func ConvertDraft07To201909(s Schema) Schema {
+	c := s.Clone()
+	c.ConvertDraft201909()
+
+	return c
+}
+
+// ConvertDraft07To202012 returns a copy of draft-07 schema converted to draft 2020-12 dialect.
+//
+// It can be used with schemas that are reflected or unmarshaled from files, see Schema.ConvertDraft202012.
+func ConvertDraft07To202012(s Schema) Schema {
+	c := s.Clone()
+	c.ConvertDraft202012()
+
+	return c
+}
+
+// ConvertToOpenAPI30 returns a copy of schema converted to OpenAPI 3.0 dialect with warnings about lossy changes.
+//
+// It can be used with schemas that are reflected or unmarshaled from files, see Schema.ConvertOpenAPI30.
+func ConvertToOpenAPI30(s Schema) (Schema, []Warning) {
+	c := s.Clone()
+	warnings := c.ConvertOpenAPI30()
+
+	return c, warnings
+}
+
+// ConvertToSwagger20 returns a copy of schema converted to Swagger 2.0 dialect with warnings about lossy changes.
+//
+// It can be used with schemas that are reflected or unmarshaled from files, see Schema.ConvertSwagger20.
+func ConvertToSwagger20(s Schema) (Schema, []Warning) {
+	c := s.Clone()
+	warnings := c.ConvertSwagger20()
+
+	return c, warnings
+}
@@ -0,0 +1,71 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestConvertToOpenAPI30(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+	  "$schema":"http://json-schema.org/draft-07/schema#",
+	  "type":["object","null"],
+	  "properties":{"id":{"type":"integer","exclusiveMinimum":0}},
+	  "propertyNames":{"pattern":"^[a-z]+$"},
+	  "definitions":{"Pair":{"type":"array","items":[{"type":"string"},{"type":"integer"}]}}
+	}`)))
+
+	orig, err := s.MarshalJSON()
+	require.NoError(t, err)
+
+	c, warnings := jsonschema.ConvertToOpenAPI30(s)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"Pair":{"items":{"anyOf":[{"type":"string"},{"type":"integer"}]},"type":"array"}
+	  },
+	  "properties":{"id":{"minimum":0,"type":"integer","exclusiveMinimum":true}},
+	  "type":"object","nullable":true
+	}`, c)
+
+	assertjson.EqMarshal(t, `[{"path":"#","message":"propertyNames is not supported and was removed"}]`, warnings)
+
+	c, warnings = jsonschema.ConvertToSwagger20(s)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{"Pair":{"items":{},"type":"array"}},
+	  "properties":{"id":{"minimum":0,"type":"integer","exclusiveMinimum":true}},
+	  "type":"object","x-nullable":true
+	}`, c)
+	assert.Len(t, warnings, 2)
+
+	c = jsonschema.ConvertDraft07To202012(s)
+
+	assertjson.EqMarshal(t, `{
+	  "$schema":"https://json-schema.org/draft/2020-12/schema",
+	  "properties":{"id":{"exclusiveMinimum":0,"type":"integer"}},
+	  "propertyNames":{"pattern":"^[a-z]+$"},"type":["object","null"],
+	  "$defs":{
+		"Pair":{"type":"array","prefixItems":[{"type":"string"},{"type":"integer"}]}
+	  }
+	}`, c)
+
+	c = jsonschema.ConvertDraft07To201909(s)
+
+	assertjson.EqMarshal(t, `{
+	  "$schema":"https://json-schema.org/draft/2019-09/schema",
+	  "properties":{"id":{"exclusiveMinimum":0,"type":"integer"}},
+	  "propertyNames":{"pattern":"^[a-z]+$"},"type":["object","null"],
+	  "$defs":{"Pair":{"items":[{"type":"string"},{"type":"integer"}],"type":"array"}}
+	}`, c)
+
+	// Original schema is not changed.
+	after, err := s.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, string(orig), string(after))
+}
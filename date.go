@@ -3,6 +3,9 @@ package jsonschema
 import (
 	"encoding"
 	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -52,3 +55,112 @@ func (d *Date) UnmarshalJSON(data []byte) error {
 func (d Date) MarshalJSON() ([]byte, error) {
 	return json.Marshal(time.Time(d).Format(DateLayout))
 }
+
+// timeLayoutElements maps elements of time layout to regular expressions of their values,
+// longer elements are listed before their prefixes.
+var timeLayoutElements = []struct {
+	element string
+	pattern string
+}{
+	{"January", "[A-Z][a-z]+"},
+	{"Jan", "[A-Z][a-z]{2}"},
+	{"Monday", "[A-Z][a-z]+"},
+	{"Mon", "[A-Z][a-z]{2}"},
+	{"MST", "[A-Z]{3,5}"},
+	{"2006", "[0-9]{4}"},
+	{"002", "[0-9]{3}"},
+	{"__2", "[ 0-9]{2}[0-9]"},
+	{"_2", "[ 0-9][0-9]"},
+	{"01", "[0-9]{2}"},
+	{"02", "[0-9]{2}"},
+	{"03", "[0-9]{2}"},
+	{"04", "[0-9]{2}"},
+	{"05", "[0-9]{2}"},
+	{"06", "[0-9]{2}"},
+	{"15", "[0-9]{2}"},
+	{"1", "[0-9]{1,2}"},
+	{"2", "[0-9]{1,2}"},
+	{"3", "[0-9]{1,2}"},
+	{"4", "[0-9]{1,2}"},
+	{"5", "[0-9]{1,2}"},
+	{"PM", "(AM|PM)"},
+	{"pm", "(am|pm)"},
+	{"Z07:00:00", "(Z|[+-][0-9]{2}:[0-9]{2}:[0-9]{2})"},
+	{"Z070000", "(Z|[+-][0-9]{6})"},
+	{"Z07:00", "(Z|[+-][0-9]{2}:[0-9]{2})"},
+	{"Z0700", "(Z|[+-][0-9]{4})"},
+	{"Z07", "(Z|[+-][0-9]{2})"},
+	{"-07:00:00", "[+-][0-9]{2}:[0-9]{2}:[0-9]{2}"},
+	{"-070000", "[+-][0-9]{6}"},
+	{"-07:00", "[+-][0-9]{2}:[0-9]{2}"},
+	{"-0700", "[+-][0-9]{4}"},
+	{"-07", "[+-][0-9]{2}"},
+}
+
+// timeFormat describes string value of time formatted with layout (as in time.Time.Format).
+//
+// RFC 3339 and DateLayout are described with `date-time` and `date` formats,
+// other layouts are described with a pattern.
+func timeFormat(schema *Schema, layout string) {
+	switch layout {
+	case time.RFC3339, time.RFC3339Nano:
+		schema.WithFormat("date-time")
+		schema.Pattern = nil
+	case DateLayout:
+		schema.WithFormat("date")
+		schema.Pattern = nil
+	default:
+		schema.Format = nil
+		schema.WithPattern(timeLayoutPattern(layout))
+	}
+}
+
+// timeLayoutPattern returns regular expression that matches values formatted with layout.
+func timeLayoutPattern(layout string) string {
+	var b strings.Builder
+
+	b.WriteString("^")
+
+layout:
+	for len(layout) > 0 {
+		// Fractional seconds, e.g. ".000" or ",999".
+		if layout[0] == '.' || layout[0] == ',' {
+			n := 1
+			for n < len(layout) && layout[n] == layout[1] && (layout[1] == '0' || layout[1] == '9') {
+				n++
+			}
+
+			if n > 1 && (n == len(layout) || layout[n] < '0' || layout[n] > '9') {
+				sep := regexp.QuoteMeta(layout[:1])
+
+				if layout[1] == '0' {
+					b.WriteString(sep + "[0-9]{" + strconv.Itoa(n-1) + "}")
+				} else {
+					b.WriteString("(" + sep + "[0-9]+)?")
+				}
+
+				layout = layout[n:]
+
+				continue
+			}
+		}
+
+		for _, e := range timeLayoutElements {
+			if strings.HasPrefix(layout, e.element) {
+				b.WriteString(e.pattern)
+
+				layout = layout[len(e.element):]
+
+				continue layout
+			}
+		}
+
+		b.WriteString(regexp.QuoteMeta(layout[:1]))
+
+		layout = layout[1:]
+	}
+
+	b.WriteString("$")
+
+	return b.String()
+}
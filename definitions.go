@@ -0,0 +1,87 @@
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefinitionsSink receives named schemas, e.g. to stream or persist them with CollectDefinitionsTo.
+type DefinitionsSink interface {
+	Put(name string, schema Schema) error
+}
+
+// DefinitionsSinkFunc implements DefinitionsSink with a function.
+type DefinitionsSinkFunc func(name string, schema Schema) error
+
+// Put calls f(name, schema).
+func (f DefinitionsSinkFunc) Put(name string, schema Schema) error {
+	return f(name, schema)
+}
+
+// Definitions is a registry of named schemas, e.g. populated with CollectDefinitionsTo.
+//
+// Example:
+//
+//	defs := jsonschema.Definitions{}
+//
+//	_, err := r.Reflect(MyType{}, jsonschema.CollectDefinitionsTo(&defs))
+type Definitions map[string]Schema
+
+// Add registers named schema.
+//
+// Adding the same name again is allowed if schema content is equal (see Schema.Equal),
+// otherwise error is returned and existing schema is kept.
+func (d *Definitions) Add(name string, schema Schema) error {
+	if *d == nil {
+		*d = make(Definitions)
+	}
+
+	if existing, found := (*d)[name]; found {
+		if existing.Equal(schema) {
+			return nil
+		}
+
+		return fmt.Errorf("conflicting definition %s", name)
+	}
+
+	(*d)[name] = schema
+
+	return nil
+}
+
+// Put registers named schema, it implements DefinitionsSink.
+func (d *Definitions) Put(name string, schema Schema) error {
+	return d.Add(name, schema)
+}
+
+// MergeInto adds definitions to target schema.
+//
+// Error is returned if target already has a different definition with the same name,
+// definitions are not added in that case.
+func (d *Definitions) MergeInto(target *Schema) error {
+	names := make([]string, 0, len(*d))
+
+	for name := range *d {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		existing, found := target.Definitions[name]
+		if !found {
+			continue
+		}
+
+		if existing.TypeObject == nil || !existing.TypeObject.Equal((*d)[name]) {
+			return fmt.Errorf("conflicting definition %s", name)
+		}
+	}
+
+	for _, name := range names {
+		schema := (*d)[name]
+		target.WithDefinitionsItem(name, schema.ToSchemaOrBool())
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package jsonschema_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestDefinitions_Add(t *testing.T) {
+	type Item struct {
+		Name string `json:"name"`
+	}
+
+	type Order struct {
+		Items []Item `json:"items"`
+	}
+
+	type Cart struct {
+		Items []Item `json:"items"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	var defs jsonschema.Definitions
+
+	collect := jsonschema.CollectDefinitions(func(name string, schema jsonschema.Schema) {
+		require.NoError(t, defs.Add(name, schema))
+	})
+
+	order, err := r.Reflect(Order{}, collect)
+	require.NoError(t, err)
+
+	_, err = r.Reflect(Cart{}, collect)
+	require.NoError(t, err)
+
+	assert.Len(t, defs, 1)
+
+	err = defs.Add("JsonschemaGoTestItem", *jsonschema.String.ToSchemaOrBool().TypeObject)
+	assert.EqualError(t, err, "conflicting definition JsonschemaGoTestItem")
+
+	require.NoError(t, defs.MergeInto(&order))
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestItem":{"properties":{"name":{"type":"string"}},"type":"object"}
+	  },
+	  "properties":{
+		"items":{"items":{"$ref":"#/definitions/JsonschemaGoTestItem"},"type":["array","null"]}
+	  },
+	  "type":"object"
+	}`, order)
+
+	require.NoError(t, defs.MergeInto(&order))
+
+	order.Definitions["JsonschemaGoTestItem"] = jsonschema.String.ToSchemaOrBool()
+	assert.EqualError(t, defs.MergeInto(&order), "conflicting definition JsonschemaGoTestItem")
+}
+
+func TestCollectDefinitionsTo(t *testing.T) {
+	type Item struct {
+		Name string `json:"name"`
+	}
+
+	type Order struct {
+		Items    []Item `json:"items"`
+		Discount *Item  `json:"discount"`
+		Total    struct {
+			Amount int `json:"amount"`
+		} `json:"total"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	var (
+		defs  jsonschema.Definitions
+		names []string
+	)
+
+	s, err := r.Reflect(Order{}, jsonschema.CollectDefinitionsTo(&defs))
+	require.NoError(t, err)
+	assert.Empty(t, s.Definitions)
+	assert.Len(t, defs, 1)
+
+	_, err = r.Reflect(Order{}, jsonschema.RootRef, jsonschema.CollectDefinitionsTo(
+		jsonschema.DefinitionsSinkFunc(func(name string, _ jsonschema.Schema) error {
+			names = append(names, name)
+
+			return nil
+		})))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"JsonschemaGoTestItem", "JsonschemaGoTestOrder"}, names)
+
+	_, err = r.Reflect(Order{}, jsonschema.CollectDefinitionsTo(
+		jsonschema.DefinitionsSinkFunc(func(_ string, _ jsonschema.Schema) error {
+			return errors.New("failed")
+		})))
+	assert.EqualError(t, err, "put definition JsonschemaGoTestItem: failed")
+}
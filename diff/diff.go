@@ -0,0 +1,477 @@
+// Package diff compares JSON Schemas and detects breaking changes.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// Kind describes type of change.
+type Kind string
+
+// Change kinds.
+const (
+	PropertyAdded       = Kind("property-added")
+	PropertyRemoved     = Kind("property-removed")
+	RequiredAdded       = Kind("required-added")
+	RequiredRemoved     = Kind("required-removed")
+	TypeAdded           = Kind("type-added")
+	TypeRemoved         = Kind("type-removed")
+	EnumValueAdded      = Kind("enum-value-added")
+	EnumValueRemoved    = Kind("enum-value-removed")
+	ConstraintTightened = Kind("constraint-tightened")
+	ConstraintLoosened  = Kind("constraint-loosened")
+	ConstraintChanged   = Kind("constraint-changed")
+)
+
+// Change describes a difference between two schemas.
+//
+// Writers produce values that are valid for schema, readers consume such values.
+// Tightened schema may reject values of existing writers, loosened schema may
+// provide values that existing readers do not expect.
+type Change struct {
+	// Path is a JSON pointer of changed schema, e.g. "#/properties/foo/items".
+	Path    string `json:"path"`
+	Kind    Kind   `json:"kind"`
+	Message string `json:"message"`
+
+	// BreaksReaders is true if change may provide values that were not valid before.
+	BreaksReaders bool `json:"breaksReaders"`
+
+	// BreaksWriters is true if change may reject values that were valid before.
+	BreaksWriters bool `json:"breaksWriters"`
+}
+
+// Breaking is true if change breaks readers or writers.
+func (c Change) Breaking() bool {
+	return c.BreaksReaders || c.BreaksWriters
+}
+
+// String implements fmt.Stringer.
+func (c Change) String() string {
+	return c.Path + ": " + c.Message
+}
+
+// Compare returns changes between two versions of a schema.
+//
+// Local references are resolved against definitions of respective root schemas.
+func Compare(oldSchema, newSchema jsonschema.Schema) []Change {
+	c := comparer{
+		oldRoot: &oldSchema,
+		newRoot: &newSchema,
+		seen:    map[[2]string]bool{},
+	}
+
+	c.compare("#", &oldSchema, &newSchema)
+
+	return c.changes
+}
+
+// Breaking filters breaking changes.
+func Breaking(changes []Change) []Change {
+	var res []Change
+
+	for _, c := range changes {
+		if c.Breaking() {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+type comparer struct {
+	oldRoot, newRoot *jsonschema.Schema
+	seen             map[[2]string]bool
+	changes          []Change
+}
+
+func (c *comparer) add(path string, kind Kind, breaksReaders, breaksWriters bool, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Path:          path,
+		Kind:          kind,
+		Message:       fmt.Sprintf(format, args...),
+		BreaksReaders: breaksReaders,
+		BreaksWriters: breaksWriters,
+	})
+}
+
+func (c *comparer) tightened(path, format string, args ...interface{}) {
+	c.add(path, ConstraintTightened, false, true, format, args...)
+}
+
+func (c *comparer) loosened(path, format string, args ...interface{}) {
+	c.add(path, ConstraintLoosened, true, false, format, args...)
+}
+
+func (c *comparer) resolve(root, s *jsonschema.Schema) (*jsonschema.Schema, string) {
+	if s == nil || s.Ref == nil {
+		return s, ""
+	}
+
+	ref := *s.Ref
+
+	if rs, found := root.ResolveRef(ref); found {
+		return rs, ref
+	}
+
+	return s, ref
+}
+
+func (c *comparer) compare(path string, oldSchema, newSchema *jsonschema.Schema) {
+	oldSchema, oldRef := c.resolve(c.oldRoot, oldSchema)
+	newSchema, newRef := c.resolve(c.newRoot, newSchema)
+
+	if oldRef != "" || newRef != "" {
+		key := [2]string{oldRef, newRef}
+		if c.seen[key] {
+			return
+		}
+
+		c.seen[key] = true
+		defer delete(c.seen, key)
+	}
+
+	if oldSchema == nil {
+		oldSchema = &jsonschema.Schema{}
+	}
+
+	if newSchema == nil {
+		newSchema = &jsonschema.Schema{}
+	}
+
+	c.compareTypes(path, oldSchema, newSchema)
+	c.compareEnum(path, oldSchema, newSchema)
+	c.compareBounds(path, oldSchema, newSchema)
+	c.compareStrings(path, oldSchema, newSchema)
+	c.compareObjects(path, oldSchema, newSchema)
+
+	c.compareSchemaOrBool(path+"/additionalProperties", oldSchema.AdditionalProperties, newSchema.AdditionalProperties)
+	c.compareSchemaOrBool(path+"/unevaluatedProperties", oldSchema.UnevaluatedProperties, newSchema.UnevaluatedProperties)
+
+	var oldItems, newItems *jsonschema.SchemaOrBool
+
+	if oldSchema.Items != nil {
+		oldItems = oldSchema.Items.SchemaOrBool
+	}
+
+	if newSchema.Items != nil {
+		newItems = newSchema.Items.SchemaOrBool
+	}
+
+	c.compareSchemaOrBool(path+"/items", oldItems, newItems)
+}
+
+func (c *comparer) compareSchemaOrBool(path string, oldSchema, newSchema *jsonschema.SchemaOrBool) {
+	switch {
+	case isFalseSchema(oldSchema) && !isFalseSchema(newSchema):
+		c.loosened(path, "allowed")
+
+		return
+	case !isFalseSchema(oldSchema) && isFalseSchema(newSchema):
+		c.tightened(path, "disallowed")
+
+		return
+	case isFalseSchema(oldSchema) && isFalseSchema(newSchema):
+		return
+	}
+
+	var o, n *jsonschema.Schema
+
+	if oldSchema != nil {
+		o = oldSchema.TypeObject
+	}
+
+	if newSchema != nil {
+		n = newSchema.TypeObject
+	}
+
+	if o == nil && n == nil {
+		return
+	}
+
+	c.compare(path, o, n)
+}
+
+func simpleTypes(s *jsonschema.Schema) []jsonschema.SimpleType {
+	if s.Type == nil {
+		return nil
+	}
+
+	if s.Type.SimpleTypes != nil {
+		return []jsonschema.SimpleType{*s.Type.SimpleTypes}
+	}
+
+	return s.Type.SliceOfSimpleTypeValues
+}
+
+func hasType(s *jsonschema.Schema, t jsonschema.SimpleType) bool {
+	if s.Type == nil {
+		return true
+	}
+
+	if s.HasType(t) {
+		return true
+	}
+
+	return t == jsonschema.Integer && s.HasType(jsonschema.Number)
+}
+
+func (c *comparer) compareTypes(path string, oldSchema, newSchema *jsonschema.Schema) {
+	if oldSchema.Type == nil && newSchema.Type != nil {
+		c.add(path, TypeRemoved, false, true, "type restricted to %v", simpleTypes(newSchema))
+
+		return
+	}
+
+	for _, t := range simpleTypes(oldSchema) {
+		if !hasType(newSchema, t) {
+			c.add(path, TypeRemoved, false, true, "type %s removed", t)
+		}
+	}
+
+	for _, t := range simpleTypes(newSchema) {
+		if !hasType(oldSchema, t) {
+			c.add(path, TypeAdded, true, false, "type %s added", t)
+		}
+	}
+}
+
+func (c *comparer) compareEnum(path string, oldSchema, newSchema *jsonschema.Schema) {
+	if len(oldSchema.Enum) == 0 && len(newSchema.Enum) > 0 {
+		c.tightened(path, "enum added")
+
+		return
+	}
+
+	if len(oldSchema.Enum) > 0 && len(newSchema.Enum) == 0 {
+		c.loosened(path, "enum removed")
+
+		return
+	}
+
+	for _, v := range oldSchema.Enum {
+		if !contains(newSchema.Enum, v) {
+			c.add(path, EnumValueRemoved, false, true, "enum value %v removed", v)
+		}
+	}
+
+	for _, v := range newSchema.Enum {
+		if !contains(oldSchema.Enum, v) {
+			c.add(path, EnumValueAdded, true, false, "enum value %v added", v)
+		}
+	}
+}
+
+func contains(values []interface{}, v interface{}) bool {
+	for _, vv := range values {
+		if jsonValue(vv) == jsonValue(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonValue returns JSON representation of a value to compare values of different Go types.
+func jsonValue(v interface{}) string {
+	j, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+
+	return string(j)
+}
+
+// compareLowerBound reports changes of a lower bound, e.g. minimum, nil bound is unlimited.
+func (c *comparer) compareLowerBound(path, name string, oldVal, newVal *float64) {
+	switch {
+	case oldVal == nil && newVal == nil:
+	case oldVal == nil:
+		c.tightened(path, "%s %v added", name, *newVal)
+	case newVal == nil:
+		c.loosened(path, "%s %v removed", name, *oldVal)
+	case *newVal > *oldVal:
+		c.tightened(path, "%s increased from %v to %v", name, *oldVal, *newVal)
+	case *newVal < *oldVal:
+		c.loosened(path, "%s decreased from %v to %v", name, *oldVal, *newVal)
+	}
+}
+
+// compareUpperBound reports changes of an upper bound, e.g. maximum, nil bound is unlimited.
+func (c *comparer) compareUpperBound(path, name string, oldVal, newVal *float64) {
+	switch {
+	case oldVal == nil && newVal == nil:
+	case oldVal == nil:
+		c.tightened(path, "%s %v added", name, *newVal)
+	case newVal == nil:
+		c.loosened(path, "%s %v removed", name, *oldVal)
+	case *newVal < *oldVal:
+		c.tightened(path, "%s decreased from %v to %v", name, *oldVal, *newVal)
+	case *newVal > *oldVal:
+		c.loosened(path, "%s increased from %v to %v", name, *oldVal, *newVal)
+	}
+}
+
+func intBound(v *int64) *float64 {
+	if v == nil {
+		return nil
+	}
+
+	f := float64(*v)
+
+	return &f
+}
+
+func minBound(v int64) *float64 {
+	if v == 0 {
+		return nil
+	}
+
+	return intBound(&v)
+}
+
+func (c *comparer) compareBounds(path string, oldSchema, newSchema *jsonschema.Schema) {
+	c.compareLowerBound(path, "minimum", oldSchema.Minimum, newSchema.Minimum)
+	c.compareLowerBound(path, "exclusiveMinimum", oldSchema.ExclusiveMinimum, newSchema.ExclusiveMinimum)
+	c.compareUpperBound(path, "maximum", oldSchema.Maximum, newSchema.Maximum)
+	c.compareUpperBound(path, "exclusiveMaximum", oldSchema.ExclusiveMaximum, newSchema.ExclusiveMaximum)
+
+	c.compareLowerBound(path, "minLength", minBound(oldSchema.MinLength), minBound(newSchema.MinLength))
+	c.compareUpperBound(path, "maxLength", intBound(oldSchema.MaxLength), intBound(newSchema.MaxLength))
+	c.compareLowerBound(path, "minItems", minBound(oldSchema.MinItems), minBound(newSchema.MinItems))
+	c.compareUpperBound(path, "maxItems", intBound(oldSchema.MaxItems), intBound(newSchema.MaxItems))
+	c.compareLowerBound(path, "minContains", intBound(oldSchema.MinContains), intBound(newSchema.MinContains))
+	c.compareLowerBound(path, "minProperties", minBound(oldSchema.MinProperties), minBound(newSchema.MinProperties))
+	c.compareUpperBound(path, "maxProperties", intBound(oldSchema.MaxProperties), intBound(newSchema.MaxProperties))
+
+	oldUnique := oldSchema.UniqueItems != nil && *oldSchema.UniqueItems
+	newUnique := newSchema.UniqueItems != nil && *newSchema.UniqueItems
+
+	switch {
+	case !oldUnique && newUnique:
+		c.tightened(path, "uniqueItems added")
+	case oldUnique && !newUnique:
+		c.loosened(path, "uniqueItems removed")
+	}
+
+	switch {
+	case oldSchema.MultipleOf == nil && newSchema.MultipleOf != nil:
+		c.tightened(path, "multipleOf %v added", *newSchema.MultipleOf)
+	case oldSchema.MultipleOf != nil && newSchema.MultipleOf == nil:
+		c.loosened(path, "multipleOf %v removed", *oldSchema.MultipleOf)
+	case oldSchema.MultipleOf != nil && *oldSchema.MultipleOf != *newSchema.MultipleOf:
+		c.add(path, ConstraintChanged, true, true, "multipleOf changed from %v to %v",
+			*oldSchema.MultipleOf, *newSchema.MultipleOf)
+	}
+}
+
+func (c *comparer) compareStringConstraint(path, name string, oldVal, newVal *string) {
+	switch {
+	case oldVal == nil && newVal == nil:
+	case oldVal == nil:
+		c.tightened(path, "%s %q added", name, *newVal)
+	case newVal == nil:
+		c.loosened(path, "%s %q removed", name, *oldVal)
+	case *oldVal != *newVal:
+		c.add(path, ConstraintChanged, true, true, "%s changed from %q to %q", name, *oldVal, *newVal)
+	}
+}
+
+func (c *comparer) compareStrings(path string, oldSchema, newSchema *jsonschema.Schema) {
+	c.compareStringConstraint(path, "pattern", oldSchema.Pattern, newSchema.Pattern)
+	c.compareStringConstraint(path, "format", oldSchema.Format, newSchema.Format)
+
+	var oldConst, newConst *string
+
+	if oldSchema.Const != nil {
+		s := jsonValue(*oldSchema.Const)
+		oldConst = &s
+	}
+
+	if newSchema.Const != nil {
+		s := jsonValue(*newSchema.Const)
+		newConst = &s
+	}
+
+	c.compareStringConstraint(path, "const", oldConst, newConst)
+}
+
+func (c *comparer) compareObjects(path string, oldSchema, newSchema *jsonschema.Schema) {
+	oldRequired := map[string]bool{}
+	newRequired := map[string]bool{}
+
+	for _, r := range oldSchema.Required {
+		oldRequired[r] = true
+	}
+
+	for _, r := range newSchema.Required {
+		newRequired[r] = true
+	}
+
+	for _, r := range sortedKeys(oldRequired) {
+		if !newRequired[r] {
+			c.add(path, RequiredRemoved, true, false, "property %s is not required anymore", r)
+		}
+	}
+
+	for _, r := range sortedKeys(newRequired) {
+		if !oldRequired[r] {
+			c.add(path, RequiredAdded, false, true, "property %s is required", r)
+		}
+	}
+
+	names := map[string]bool{}
+
+	for name := range oldSchema.Properties {
+		names[name] = true
+	}
+
+	for name := range newSchema.Properties {
+		names[name] = true
+	}
+
+	for _, name := range sortedKeys(names) {
+		pp := path + "/properties/" + escape(name)
+		oldProp, inOld := oldSchema.Properties[name]
+		newProp, inNew := newSchema.Properties[name]
+
+		switch {
+		case !inOld:
+			c.add(pp, PropertyAdded, false, false, "property %s added", name)
+		case !inNew:
+			c.add(pp, PropertyRemoved, true, isClosed(newSchema), "property %s removed", name)
+		default:
+			c.compareSchemaOrBool(pp, &oldProp, &newProp)
+		}
+	}
+}
+
+func isFalseSchema(s *jsonschema.SchemaOrBool) bool {
+	return s != nil && s.TypeBoolean != nil && !*s.TypeBoolean
+}
+
+// isClosed is true if schema does not allow undeclared properties.
+func isClosed(s *jsonschema.Schema) bool {
+	return isFalseSchema(s.AdditionalProperties)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func escape(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
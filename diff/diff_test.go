@@ -0,0 +1,102 @@
+package diff_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/jsonschema-go/diff"
+)
+
+func TestCompare(t *testing.T) {
+	type Item struct {
+		Name string `json:"name" maxLength:"10"`
+	}
+
+	type OldOrder struct {
+		ID     int     `json:"id" required:"true"`
+		Status string  `json:"status" enum:"new,paid"`
+		Note   string  `json:"note"`
+		Price  float64 `json:"price" minimum:"0"`
+		Items  []Item  `json:"items"`
+	}
+
+	type NewItem struct {
+		Name string `json:"name" maxLength:"5"`
+	}
+
+	type NewOrder struct {
+		ID     string    `json:"id" required:"true"`
+		Status string    `json:"status" enum:"new,paid,shipped"`
+		Price  float64   `json:"price" minimum:"1" required:"true"`
+		Items  []NewItem `json:"items"`
+		Extra  bool      `json:"extra"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	oldSchema, err := r.Reflect(OldOrder{}, jsonschema.InterceptDefName(func(_ reflect.Type, _ string) string {
+		return "Item"
+	}))
+	require.NoError(t, err)
+
+	newSchema, err := r.Reflect(NewOrder{}, jsonschema.InterceptDefName(func(_ reflect.Type, _ string) string {
+		return "Item"
+	}))
+	require.NoError(t, err)
+
+	changes := diff.Compare(oldSchema, newSchema)
+
+	var lines []string
+	for _, c := range changes {
+		lines = append(lines, c.String())
+	}
+
+	assert.Equal(t, []string{
+		"#: property price is required",
+		"#/properties/extra: property extra added",
+		"#/properties/id: type integer removed",
+		"#/properties/id: type string added",
+		"#/properties/items/items/properties/name: maxLength decreased from 10 to 5",
+		"#/properties/note: property note removed",
+		"#/properties/price: minimum increased from 0 to 1",
+		"#/properties/status: enum value shipped added",
+	}, lines)
+
+	breaking := diff.Breaking(changes)
+	require.Len(t, breaking, 7)
+
+	for _, c := range breaking {
+		switch c.Kind {
+		case diff.TypeAdded, diff.EnumValueAdded, diff.PropertyRemoved:
+			assert.True(t, c.BreaksReaders, c.String())
+			assert.False(t, c.BreaksWriters, c.String())
+		default:
+			assert.False(t, c.BreaksReaders, c.String())
+			assert.True(t, c.BreaksWriters, c.String())
+		}
+	}
+
+	assert.Empty(t, diff.Compare(newSchema, newSchema))
+}
+
+func TestCompare_recursive(t *testing.T) {
+	var oldSchema, newSchema jsonschema.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "definitions":{"Node":{"properties":{"next":{"$ref":"#/definitions/Node"},"v":{"type":"integer"}}}},
+	  "$ref":"#/definitions/Node"
+	}`), &oldSchema))
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "definitions":{"Node":{"properties":{"next":{"$ref":"#/definitions/Node"},"v":{"type":"number"}},"additionalProperties":false}},
+	  "$ref":"#/definitions/Node"
+	}`), &newSchema))
+
+	assert.Equal(t, []diff.Change{
+		{Path: "#/properties/v", Kind: diff.TypeAdded, Message: "type number added", BreaksReaders: true},
+		{Path: "#/additionalProperties", Kind: diff.ConstraintTightened, Message: "disallowed", BreaksWriters: true},
+	}, diff.Compare(oldSchema, newSchema))
+}
@@ -0,0 +1,28 @@
+package jsonschema
+
+// Draft201909Schema is the URI of JSON Schema draft 2019-09 meta schema.
+const Draft201909Schema = "https://json-schema.org/draft/2019-09/schema"
+
+// Draft201909 enables reflection of JSON Schema draft 2019-09 dialect, see Schema.ConvertDraft201909.
+func Draft201909(rc *ReflectContext) {
+	rc.Draft201909 = true
+}
+
+// ConvertDraft201909 converts draft-07 keywords of schema and its subschemas to draft 2019-09 dialect.
+//
+// Conversion is done in place:
+//   - `definitions` are moved to `$defs` and local references are updated,
+//   - `dependencies` are split into `dependentSchemas` and `dependentRequired`,
+//   - `additionalProperties: false` next to `allOf` is replaced with `unevaluatedProperties: false`,
+//     so that properties of `allOf` items are not rejected,
+//   - draft-07 `$schema` is replaced with Draft201909Schema.
+//
+// Tuple `items` and `additionalItems` are kept as they are valid in draft 2019-09.
+//
+// Keywords without Schema fields (e.g. `$defs`, `dependentSchemas`) are stored in Schema.ExtraProperties,
+// so converted schema should only be used for marshaling.
+// Schema.RecursiveRef, Schema.MinContains and Schema.DependentRequired are draft 2019-09 keywords that
+// can be set explicitly.
+func (s *Schema) ConvertDraft201909() {
+	convertDraft(s, Draft201909Schema, false)
+}
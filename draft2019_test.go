@@ -0,0 +1,84 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestSchema_ConvertDraft201909(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+	  "$schema":"http://json-schema.org/draft-07/schema#",
+	  "properties":{
+		"point":{"type":"array","items":[{"type":"number"},{"type":"number"}],"additionalItems":false},
+		"parent":{"$ref":"#/definitions/Node"}
+	  },
+	  "dependencies":{"card":["billing"]},
+	  "definitions":{"Node":{"properties":{"children":{"items":{"$ref":"#/definitions/Node"}}}}}
+	}`)))
+
+	s.ConvertDraft201909()
+
+	assertjson.EqMarshal(t, `{
+	  "$schema":"https://json-schema.org/draft/2019-09/schema",
+	  "properties":{
+		"point":{"additionalItems":false,"items":[{"type":"number"},{"type":"number"}],"type":"array"},
+		"parent":{"$ref":"#/$defs/Node"}
+	  },
+	  "$defs":{"Node":{"properties":{"children":{"items":{"$ref":"#/$defs/Node"}}}}},
+	  "dependentRequired":{"card":["billing"]}
+	}`, s)
+}
+
+func TestDraft201909(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	var collected []string
+
+	s, err := r.Reflect(Draft2020Order{}, jsonschema.Draft201909,
+		jsonschema.CollectDefinitions(func(name string, schema jsonschema.Schema) {
+			collected = append(collected, name)
+		}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"JsonschemaGoTestDraft2020Base"}, collected)
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"items":{"items":{"$ref":"#/$defs/JsonschemaGoTestDraft2020Base"},"type":["array","null"]}
+	  },
+	  "type":"object","allOf":[{"$ref":"#/$defs/JsonschemaGoTestDraft2020Base"}],
+	  "unevaluatedProperties":false
+	}`, s)
+}
+
+func TestSchema_draft201909Keywords(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "$recursiveRef":"#",
+	  "properties":{"tags":{"contains":{"const":"a"},"minContains":2}},
+	  "dependentRequired":{"card":["billing"]}
+	}`), &s))
+
+	require.NotNil(t, s.RecursiveRef)
+	assert.Equal(t, "#", *s.RecursiveRef)
+	assert.Equal(t, int64(2), *s.Properties["tags"].TypeObject.MinContains)
+	assert.Equal(t, map[string][]string{"card": {"billing"}}, s.DependentRequired)
+	assert.Empty(t, s.ExtraProperties)
+
+	assertjson.EqMarshal(t, `{
+	  "$recursiveRef":"#",
+	  "properties":{"tags":{"contains":{"const":"a"},"minContains":2}},
+	  "dependentRequired":{"card":["billing"]}
+	}`, s)
+
+	assert.NoError(t, s.ValidateJSON([]byte(`{"tags":["a","b","a"],"card":1,"billing":2}`)))
+	assert.EqualError(t, s.ValidateJSON([]byte(`{"tags":["a","b"],"card":1}`)),
+		`#/tags: 1 items match contains schema, less than 2, #: property "card" requires property "billing"`)
+}
@@ -0,0 +1,105 @@
+package jsonschema
+
+import "strings"
+
+// Draft202012Schema is the URI of JSON Schema draft 2020-12 meta schema.
+const Draft202012Schema = "https://json-schema.org/draft/2020-12/schema"
+
+// Draft202012 enables reflection of JSON Schema draft 2020-12 dialect, see Schema.ConvertDraft202012.
+func Draft202012(rc *ReflectContext) {
+	rc.Draft202012 = true
+}
+
+// ConvertDraft202012 converts draft-07 keywords of schema and its subschemas to draft 2020-12 dialect.
+//
+// Conversion is done in place:
+//   - `definitions` are moved to `$defs` and local references are updated,
+//   - tuple `items` are moved to `prefixItems` and `additionalItems` to `items`,
+//   - `dependencies` are split into `dependentSchemas` and `dependentRequired`,
+//   - `additionalProperties: false` next to `allOf` is replaced with `unevaluatedProperties: false`,
+//     so that properties of `allOf` items are not rejected,
+//   - draft-07 `$schema` is replaced with Draft202012Schema.
+//
+// Keywords without Schema fields (e.g. `$defs`, `prefixItems`) are stored in Schema.ExtraProperties,
+// so converted schema should only be used for marshaling.
+func (s *Schema) ConvertDraft202012() {
+	convertDraft(s, Draft202012Schema, true)
+}
+
+// convertDraft converts draft-07 schema to draft 2019-09 or 2020-12, prefixItems enables draft 2020-12 tuples.
+func convertDraft(s *Schema, schemaURI string, prefixItems bool) {
+	s.eachSubSchema(func(_ string, sub *Schema) {
+		convertDraft(sub, schemaURI, prefixItems)
+	})
+
+	if len(s.Definitions) > 0 {
+		defs := make(map[string]SchemaOrBool, len(s.Definitions))
+
+		for name, def := range s.Definitions {
+			if def.TypeObject != nil {
+				convertDraft(def.TypeObject, schemaURI, prefixItems)
+			}
+
+			defs[name] = def
+		}
+
+		s.WithExtraPropertiesItem("$defs", defs)
+		s.Definitions = nil
+	}
+
+	if s.Ref != nil && strings.HasPrefix(*s.Ref, "#/definitions/") {
+		s.WithRef("#/$defs/" + strings.TrimPrefix(*s.Ref, "#/definitions/"))
+	}
+
+	if s.Schema != nil && strings.Contains(*s.Schema, "draft-07") {
+		s.WithSchema(schemaURI)
+	}
+
+	if prefixItems {
+		if s.Items != nil && s.Items.SchemaArray != nil {
+			s.WithExtraPropertiesItem("prefixItems", s.Items.SchemaArray)
+			s.Items = nil
+
+			if s.AdditionalItems != nil {
+				s.ItemsEns().WithSchemaOrBool(*s.AdditionalItems)
+			}
+		}
+
+		s.AdditionalItems = nil
+	}
+
+	convertDependencies(s)
+
+	if len(s.AllOf) > 0 && s.AdditionalProperties != nil && s.AdditionalProperties.TypeBoolean != nil &&
+		!*s.AdditionalProperties.TypeBoolean {
+		s.WithUnevaluatedProperties(SchemaOrBool{TypeBoolean: s.AdditionalProperties.TypeBoolean})
+		s.AdditionalProperties = nil
+	}
+}
+
+func convertDependencies(s *Schema) {
+	if len(s.Dependencies) == 0 {
+		return
+	}
+
+	schemas := map[string]SchemaOrBool{}
+	required := map[string][]string{}
+
+	for name, d := range s.Dependencies {
+		if d.SchemaOrBool != nil {
+			schemas[name] = *d.SchemaOrBool
+		} else {
+			required[name] = d.StringArray
+		}
+	}
+
+	if len(schemas) > 0 {
+		s.WithExtraPropertiesItem("dependentSchemas", schemas)
+	}
+
+	for name, r := range required {
+		s.WithDependentRequiredItem(name, r)
+	}
+
+	s.Dependencies = nil
+}
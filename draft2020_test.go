@@ -0,0 +1,72 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestSchema_ConvertDraft202012(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+	  "$schema":"http://json-schema.org/draft-07/schema#",
+	  "allOf":[{"$ref":"#/definitions/Base"}],
+	  "additionalProperties":false,
+	  "properties":{
+		"point":{"type":"array","items":[{"type":"number"},{"type":"number"}],"additionalItems":false},
+		"tags":{"type":"array","items":{"type":"string"},"additionalItems":false}
+	  },
+	  "dependencies":{"card":["billing"],"vat":{"required":["country"]}},
+	  "definitions":{
+		"Base":{"properties":{"id":{"$ref":"#/definitions/ID"}}},
+		"ID":{"type":"integer"}
+	  }
+	}`)))
+
+	s.ConvertDraft202012()
+
+	assertjson.EqMarshal(t, `{
+	  "$schema":"https://json-schema.org/draft/2020-12/schema",
+	  "properties":{
+		"point":{"items":false,"type":"array","prefixItems":[{"type":"number"},{"type":"number"}]},
+		"tags":{"items":{"type":"string"},"type":"array"}
+	  },
+	  "allOf":[{"$ref":"#/$defs/Base"}],
+	  "$defs":{"Base":{"properties":{"id":{"$ref":"#/$defs/ID"}}},"ID":{"type":"integer"}},
+	  "dependentRequired":{"card":["billing"]},
+	  "dependentSchemas":{"vat":{"required":["country"]}},
+	  "unevaluatedProperties":false
+	}`, s)
+}
+
+type Draft2020Base struct {
+	ID int `json:"id"`
+}
+
+type Draft2020Order struct {
+	Draft2020Base `refer:"true"`
+	_             struct{} `additionalProperties:"false"`
+
+	Items []Draft2020Base `json:"items"`
+}
+
+func TestDraft202012(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(Draft2020Order{}, jsonschema.Draft202012)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"items":{"items":{"$ref":"#/$defs/JsonschemaGoTestDraft2020Base"},"type":["array","null"]}
+	  },
+	  "type":"object","allOf":[{"$ref":"#/$defs/JsonschemaGoTestDraft2020Base"}],
+	  "$defs":{
+		"JsonschemaGoTestDraft2020Base":{"properties":{"id":{"type":"integer"}},"type":"object"}
+	  },
+	  "unevaluatedProperties":false
+	}`, s)
+}
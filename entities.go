@@ -15,54 +15,61 @@ import (
 //
 // Core schema meta-schema.
 type Schema struct {
-	ID                   *string                                     `json:"$id,omitempty"`     // Format: uri-reference.
-	Schema               *string                                     `json:"$schema,omitempty"` // Format: uri.
-	Ref                  *string                                     `json:"$ref,omitempty"`    // Format: uri-reference.
-	Comment              *string                                     `json:"$comment,omitempty"`
-	Title                *string                                     `json:"title,omitempty"`
-	Description          *string                                     `json:"description,omitempty"`
-	Default              *interface{}                                `json:"default,omitempty"`
-	ReadOnly             *bool                                       `json:"readOnly,omitempty"`
-	Examples             []interface{}                               `json:"examples,omitempty"`
-	MultipleOf           *float64                                    `json:"multipleOf,omitempty"`
-	Maximum              *float64                                    `json:"maximum,omitempty"`
-	ExclusiveMaximum     *float64                                    `json:"exclusiveMaximum,omitempty"`
-	Minimum              *float64                                    `json:"minimum,omitempty"`
-	ExclusiveMinimum     *float64                                    `json:"exclusiveMinimum,omitempty"`
-	MaxLength            *int64                                      `json:"maxLength,omitempty"`
-	MinLength            int64                                       `json:"minLength,omitempty"`
-	Pattern              *string                                     `json:"pattern,omitempty"`         // Format: regex.
-	AdditionalItems      *SchemaOrBool                               `json:"additionalItems,omitempty"` // Core schema meta-schema.
-	Items                *Items                                      `json:"items,omitempty"`
-	MaxItems             *int64                                      `json:"maxItems,omitempty"`
-	MinItems             int64                                       `json:"minItems,omitempty"`
-	UniqueItems          *bool                                       `json:"uniqueItems,omitempty"`
-	Contains             *SchemaOrBool                               `json:"contains,omitempty"` // Core schema meta-schema.
-	MaxProperties        *int64                                      `json:"maxProperties,omitempty"`
-	MinProperties        int64                                       `json:"minProperties,omitempty"`
-	Required             []string                                    `json:"required,omitempty"`
-	AdditionalProperties *SchemaOrBool                               `json:"additionalProperties,omitempty"` // Core schema meta-schema.
-	Definitions          map[string]SchemaOrBool                     `json:"definitions,omitempty"`
-	Properties           map[string]SchemaOrBool                     `json:"properties,omitempty"`
-	PatternProperties    map[string]SchemaOrBool                     `json:"patternProperties,omitempty"`
-	Dependencies         map[string]DependenciesAdditionalProperties `json:"dependencies,omitempty"`
-	PropertyNames        *SchemaOrBool                               `json:"propertyNames,omitempty"` // Core schema meta-schema.
-	Const                *interface{}                                `json:"const,omitempty"`
-	Enum                 []interface{}                               `json:"enum,omitempty"`
-	Type                 *Type                                       `json:"type,omitempty"`
-	Format               *string                                     `json:"format,omitempty"`
-	ContentMediaType     *string                                     `json:"contentMediaType,omitempty"`
-	ContentEncoding      *string                                     `json:"contentEncoding,omitempty"`
-	If                   *SchemaOrBool                               `json:"if,omitempty"`   // Core schema meta-schema.
-	Then                 *SchemaOrBool                               `json:"then,omitempty"` // Core schema meta-schema.
-	Else                 *SchemaOrBool                               `json:"else,omitempty"` // Core schema meta-schema.
-	AllOf                []SchemaOrBool                              `json:"allOf,omitempty"`
-	AnyOf                []SchemaOrBool                              `json:"anyOf,omitempty"`
-	OneOf                []SchemaOrBool                              `json:"oneOf,omitempty"`
-	Not                  *SchemaOrBool                               `json:"not,omitempty"` // Core schema meta-schema.
-	ExtraProperties      map[string]interface{}                      `json:"-"`             // All unmatched properties.
-	ReflectType          reflect.Type                                `json:"-"`
-	Parent               *Schema                                     `json:"-"`
+	ID                    *string                                     `json:"$id,omitempty"`     // Format: uri-reference.
+	Schema                *string                                     `json:"$schema,omitempty"` // Format: uri.
+	Ref                   *string                                     `json:"$ref,omitempty"`    // Format: uri-reference.
+	Comment               *string                                     `json:"$comment,omitempty"`
+	Anchor                *string                                     `json:"$anchor,omitempty"`
+	DynamicAnchor         *string                                     `json:"$dynamicAnchor,omitempty"`
+	DynamicRef            *string                                     `json:"$dynamicRef,omitempty"`   // Format: uri-reference.
+	RecursiveRef          *string                                     `json:"$recursiveRef,omitempty"` // Format: uri-reference.
+	Title                 *string                                     `json:"title,omitempty"`
+	Description           *string                                     `json:"description,omitempty"`
+	Default               *interface{}                                `json:"default,omitempty"`
+	ReadOnly              *bool                                       `json:"readOnly,omitempty"`
+	Examples              []interface{}                               `json:"examples,omitempty"`
+	MultipleOf            *float64                                    `json:"multipleOf,omitempty"`
+	Maximum               *float64                                    `json:"maximum,omitempty"`
+	ExclusiveMaximum      *float64                                    `json:"exclusiveMaximum,omitempty"`
+	Minimum               *float64                                    `json:"minimum,omitempty"`
+	ExclusiveMinimum      *float64                                    `json:"exclusiveMinimum,omitempty"`
+	MaxLength             *int64                                      `json:"maxLength,omitempty"`
+	MinLength             int64                                       `json:"minLength,omitempty"`
+	Pattern               *string                                     `json:"pattern,omitempty"`         // Format: regex.
+	AdditionalItems       *SchemaOrBool                               `json:"additionalItems,omitempty"` // Core schema meta-schema.
+	Items                 *Items                                      `json:"items,omitempty"`
+	MaxItems              *int64                                      `json:"maxItems,omitempty"`
+	MinItems              int64                                       `json:"minItems,omitempty"`
+	UniqueItems           *bool                                       `json:"uniqueItems,omitempty"`
+	Contains              *SchemaOrBool                               `json:"contains,omitempty"` // Core schema meta-schema.
+	MinContains           *int64                                      `json:"minContains,omitempty"`
+	MaxProperties         *int64                                      `json:"maxProperties,omitempty"`
+	MinProperties         int64                                       `json:"minProperties,omitempty"`
+	Required              []string                                    `json:"required,omitempty"`
+	AdditionalProperties  *SchemaOrBool                               `json:"additionalProperties,omitempty"` // Core schema meta-schema.
+	Definitions           map[string]SchemaOrBool                     `json:"definitions,omitempty"`
+	Properties            map[string]SchemaOrBool                     `json:"properties,omitempty"`
+	PatternProperties     map[string]SchemaOrBool                     `json:"patternProperties,omitempty"`
+	Dependencies          map[string]DependenciesAdditionalProperties `json:"dependencies,omitempty"`
+	DependentRequired     map[string][]string                         `json:"dependentRequired,omitempty"`
+	PropertyNames         *SchemaOrBool                               `json:"propertyNames,omitempty"` // Core schema meta-schema.
+	Const                 *interface{}                                `json:"const,omitempty"`
+	Enum                  []interface{}                               `json:"enum,omitempty"`
+	Type                  *Type                                       `json:"type,omitempty"`
+	Format                *string                                     `json:"format,omitempty"`
+	ContentMediaType      *string                                     `json:"contentMediaType,omitempty"`
+	ContentEncoding       *string                                     `json:"contentEncoding,omitempty"`
+	If                    *SchemaOrBool                               `json:"if,omitempty"`   // Core schema meta-schema.
+	Then                  *SchemaOrBool                               `json:"then,omitempty"` // Core schema meta-schema.
+	Else                  *SchemaOrBool                               `json:"else,omitempty"` // Core schema meta-schema.
+	AllOf                 []SchemaOrBool                              `json:"allOf,omitempty"`
+	AnyOf                 []SchemaOrBool                              `json:"anyOf,omitempty"`
+	OneOf                 []SchemaOrBool                              `json:"oneOf,omitempty"`
+	Not                   *SchemaOrBool                               `json:"not,omitempty"`                   // Core schema meta-schema.
+	UnevaluatedProperties *SchemaOrBool                               `json:"unevaluatedProperties,omitempty"` // Core schema meta-schema.
+	ExtraProperties       map[string]interface{}                      `json:"-"`                               // All unmatched properties.
+	ReflectType           reflect.Type                                `json:"-"`
+	Parent                *Schema                                     `json:"-"`
 }
 
 // WithID sets ID value.
@@ -89,6 +96,30 @@ func (s *Schema) WithComment(val string) *Schema {
 	return s
 }
 
+// WithAnchor sets Anchor value.
+func (s *Schema) WithAnchor(val string) *Schema {
+	s.Anchor = &val
+	return s
+}
+
+// WithDynamicAnchor sets DynamicAnchor value.
+func (s *Schema) WithDynamicAnchor(val string) *Schema {
+	s.DynamicAnchor = &val
+	return s
+}
+
+// WithDynamicRef sets DynamicRef value.
+func (s *Schema) WithDynamicRef(val string) *Schema {
+	s.DynamicRef = &val
+	return s
+}
+
+// WithRecursiveRef sets RecursiveRef value.
+func (s *Schema) WithRecursiveRef(val string) *Schema {
+	s.RecursiveRef = &val
+	return s
+}
+
 // WithTitle sets Title value.
 func (s *Schema) WithTitle(val string) *Schema {
 	s.Title = &val
@@ -230,6 +261,12 @@ func (s *Schema) ContainsEns() *SchemaOrBool {
 	return s.Contains
 }
 
+// WithMinContains sets MinContains value.
+func (s *Schema) WithMinContains(val int64) *Schema {
+	s.MinContains = &val
+	return s
+}
+
 // WithMaxProperties sets MaxProperties value.
 func (s *Schema) WithMaxProperties(val int64) *Schema {
 	s.MaxProperties = &val
@@ -331,6 +368,23 @@ func (s *Schema) WithDependenciesItem(key string, val DependenciesAdditionalProp
 	return s
 }
 
+// WithDependentRequired sets DependentRequired value.
+func (s *Schema) WithDependentRequired(val map[string][]string) *Schema {
+	s.DependentRequired = val
+	return s
+}
+
+// WithDependentRequiredItem sets DependentRequired item value.
+func (s *Schema) WithDependentRequiredItem(key string, val []string) *Schema {
+	if s.DependentRequired == nil {
+		s.DependentRequired = make(map[string][]string, 1)
+	}
+
+	s.DependentRequired[key] = val
+
+	return s
+}
+
 // WithPropertyNames sets PropertyNames value.
 func (s *Schema) WithPropertyNames(val SchemaOrBool) *Schema {
 	s.PropertyNames = &val
@@ -469,6 +523,21 @@ func (s *Schema) NotEns() *SchemaOrBool {
 	return s.Not
 }
 
+// WithUnevaluatedProperties sets UnevaluatedProperties value.
+func (s *Schema) WithUnevaluatedProperties(val SchemaOrBool) *Schema {
+	s.UnevaluatedProperties = &val
+	return s
+}
+
+// UnevaluatedPropertiesEns ensures returned UnevaluatedProperties is not nil.
+func (s *Schema) UnevaluatedPropertiesEns() *SchemaOrBool {
+	if s.UnevaluatedProperties == nil {
+		s.UnevaluatedProperties = new(SchemaOrBool)
+	}
+
+	return s.UnevaluatedProperties
+}
+
 // WithExtraProperties sets ExtraProperties value.
 func (s *Schema) WithExtraProperties(val map[string]interface{}) *Schema {
 	s.ExtraProperties = val
@@ -493,6 +562,10 @@ var knownKeysSchema = []string{
 	"$schema",
 	"$ref",
 	"$comment",
+	"$anchor",
+	"$dynamicAnchor",
+	"$dynamicRef",
+	"$recursiveRef",
 	"title",
 	"description",
 	"default",
@@ -512,6 +585,7 @@ var knownKeysSchema = []string{
 	"minItems",
 	"uniqueItems",
 	"contains",
+	"minContains",
 	"maxProperties",
 	"minProperties",
 	"required",
@@ -520,6 +594,7 @@ var knownKeysSchema = []string{
 	"properties",
 	"patternProperties",
 	"dependencies",
+	"dependentRequired",
 	"propertyNames",
 	"const",
 	"enum",
@@ -534,6 +609,7 @@ var knownKeysSchema = []string{
 	"anyOf",
 	"oneOf",
 	"not",
+	"unevaluatedProperties",
 }
 
 // UnmarshalJSON decodes JSON.
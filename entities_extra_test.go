@@ -3,8 +3,10 @@ package jsonschema_test
 import (
 	"encoding/json"
 	"io/ioutil"
+	"math"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/swaggest/assertjson"
 	"github.com/swaggest/jsonschema-go"
@@ -87,3 +89,82 @@ func BenchmarkSchema_MarshalJSON(b *testing.B) {
 		require.NoError(b, err)
 	}
 }
+
+func TestSchema_MarshalJSON_encoding(t *testing.T) {
+	s := jsonschema.Schema{}
+	s.WithTitle(`<a href="x">&</a>`).
+		WithDescription("Line\nbreak, tab\t, unicode ü, separator \u2028.").
+		WithMinimum(1e-7).
+		WithMaximum(1e21).
+		WithMultipleOf(0.5).
+		WithMinLength(1).
+		WithDefault(map[string]interface{}{"b": 1, "a": []interface{}{nil, true, "x"}}).
+		WithEnum(1, 2.5, "three", nil).
+		WithType(jsonschema.Type{SliceOfSimpleTypeValues: []jsonschema.SimpleType{jsonschema.String, jsonschema.Null}}).
+		WithItems(*(&jsonschema.Items{}).WithSchemaArray(
+			jsonschema.SchemaOrBool{TypeBoolean: new(bool)},
+			(&jsonschema.Schema{}).ToSchemaOrBool(),
+		)).
+		WithProperties(map[string]jsonschema.SchemaOrBool{
+			"b": jsonschema.String.ToSchemaOrBool(),
+			"a": (&jsonschema.Schema{}).WithRef("#/definitions/A").ToSchemaOrBool(),
+		}).
+		WithExtraPropertiesItem("x-b", jsonschema.Integer.ToSchemaOrBool()).
+		WithExtraPropertiesItem("x-a", []string{"<"})
+
+	j, err := json.Marshal(s)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"title":"\u003ca href=\"x\"\u003e\u0026\u003c/a\u003e",`+
+		`"description":"Line\nbreak, tab\t, unicode ü, separator \u2028.",`+
+		`"default":{"a":[null,true,"x"],"b":1},"multipleOf":0.5,"maximum":1e+21,"minimum":1e-7,"minLength":1,`+
+		`"items":[false,{}],"properties":{"a":{"$ref":"#/definitions/A"},"b":{"type":"string"}},`+
+		`"enum":[1,2.5,"three",null],"type":["string","null"],"x-a":["\u003c"],"x-b":{"type":"integer"}}`, string(j))
+
+	_, err = json.Marshal(jsonschema.Schema{Not: &jsonschema.SchemaOrBool{}})
+	assert.EqualError(t, err, "json: error calling MarshalJSON for type *jsonschema.Schema: "+
+		"json: error calling MarshalJSON for type *jsonschema.marshalSchema: missing typed value")
+
+	_, err = json.Marshal(jsonschema.Schema{Maximum: new(float64), Minimum: func() *float64 { f := math.Inf(1); return &f }()})
+	assert.EqualError(t, err, "json: error calling MarshalJSON for type *jsonschema.Schema: "+
+		"json: error calling MarshalJSON for type *jsonschema.marshalSchema: json: unsupported value: +Inf")
+}
+
+func TestSchema_UnmarshalJSON_decoding(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "title": "Escaped \"title\" ü",
+	  "default": null,
+	  "minimum": 1e-7, "maxLength": 10, "minItems": 2,
+	  "items": [true, {"type": "string"}],
+	  "properties": {"a\/b": {"$ref": "#/definitions/A"}, "c": false},
+	  "allOf": [{"required": ["a/b"]}],
+	  "type": ["string", "null"],
+	  "x-extra": {"nested": [1, "]}"]}
+	}`), &s))
+
+	assertjson.EqMarshal(t, `{
+	  "title":"Escaped \"title\" ü","default":null,"minimum":1e-7,"maxLength":10,
+	  "items":[true,{"type":"string"}],"minItems":2,
+	  "properties":{"a/b":{"$ref":"#/definitions/A"},"c":false},
+	  "type":["string","null"],"allOf":[{"required":["a/b"]}],
+	  "x-extra":{"nested":[1,"]}"]}
+	}`, s)
+
+	var sb jsonschema.SchemaOrBool
+
+	require.NoError(t, json.Unmarshal([]byte(`null`), &sb))
+	assert.Nil(t, sb.TypeObject)
+	assert.Nil(t, sb.TypeBoolean)
+
+	assert.EqualError(t, json.Unmarshal([]byte(`{"title":}`), &s),
+		"invalid character '}' looking for beginning of value")
+	assert.EqualError(t, s.UnmarshalJSON([]byte(`{"title":`)), "unexpected end of JSON input")
+	assert.EqualError(t, s.UnmarshalJSON([]byte(`[]`)),
+		"json: cannot unmarshal array into Go value of type jsonschema.Schema")
+	assert.EqualError(t, s.UnmarshalJSON([]byte(`{"properties":{"a":{"minLength":"1"}}}`)),
+		"properties: a: minLength: json: cannot unmarshal string into Go value of type int64")
+	assert.EqualError(t, s.UnmarshalJSON([]byte(`{"anyOf":[true,"a"]}`)),
+		"anyOf: 1: json: cannot unmarshal string into Go value of type bool")
+}
@@ -486,15 +486,17 @@ func ExampleAllOf() {
 func ExampleReflector_Reflect_default() {
 	type MyStruct struct {
 		A []string       `json:"a" default:"[A,B,C]"` // For an array of strings, comma-separated values in square brackets can be used.
-		B []int          `json:"b" default:"[1,2,3]"` // Other non-scalar values are parsed as JSON without type checking.
+		B []int          `json:"b" default:"[1,2,3]"` // JSON arrays are used as is.
 		C []string       `json:"c" default:"[\"C\",\"B\",\"A\"]"`
 		D int            `json:"d" default:"123"` // Scalar values are parsed according to their type.
 		E string         `json:"e" default:"abc"`
-		F map[string]int `json:"f" default:"{\"foo\":1,\"bar\":2}"`
+		F map[string]int `json:"f" default:"{\"foo\":1,\"bar\":2}"` // Other non-scalar values are parsed as JSON.
+		G []float64      `json:"g" default:"[1.5, 2]"`
+		H []bool         `json:"h" default:"[true,false]"`
 	}
 
 	type Invalid struct {
-		I []int `json:"i" default:"[C,B,A]"` // Value with invalid JSON is ignored for types other than []string (and equivalent).
+		I []int `json:"i" default:"[1,B,3]"` // Non-JSON lists are type checked, items of invalid type are not allowed.
 	}
 
 	r := jsonschema.Reflector{}
@@ -515,11 +517,13 @@ func ExampleReflector_Reflect_default() {
 	//   "f":{
 	//    "default":{"bar":2,"foo":1},"additionalProperties":{"type":"integer"},
 	//    "type":["object","null"]
-	//   }
+	//   },
+	//   "g":{"default":[1.5,2],"items":{"type":"number"},"type":["array","null"]},
+	//   "h":{"default":[true,false],"items":{"type":"boolean"},"type":["array","null"]}
 	//  },
 	//  "type":"object"
 	// }
-	// Invalid error: I: parsing default as JSON: invalid character 'C' looking for beginning of value
+	// Invalid error: I: parsing default: item "B" is not a valid integer
 }
 
 func ExampleReflector_Reflect_virtualStruct() {
@@ -0,0 +1,147 @@
+// Package gen writes JSON Schema files of Go types, it is intended for go:generate hooks.
+//
+// Example of generator program invoked with `//go:generate go run ./schemagen`:
+//
+//	func main() {
+//		g := gen.Generator{Dir: "schemas"}
+//
+//		if err := g.Write(User{}, Order{}); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// Generator writes one schema file per sample value.
+type Generator struct {
+	// Dir is a target directory, it is created if missing.
+	Dir string
+
+	// Reflector is used to reflect samples, zero value is used if nil.
+	Reflector *jsonschema.Reflector
+
+	// ReflectOptions are applied to every reflection.
+	ReflectOptions []func(rc *jsonschema.ReflectContext)
+
+	// Indent is used to format JSON, default two spaces.
+	Indent string
+
+	// FileName returns name of schema file, default is definition name with ".json" extension.
+	//
+	// Definition name is empty for types that are not named, such files need custom name.
+	FileName func(sample interface{}, defName string) string
+}
+
+// Write reflects samples and writes schema files to target directory.
+//
+// Files are written with stable formatting and trailing new line, unchanged files are not rewritten.
+func (g Generator) Write(samples ...interface{}) error {
+	r := g.Reflector
+	if r == nil {
+		r = &jsonschema.Reflector{}
+	}
+
+	if err := os.MkdirAll(g.Dir, 0o750); err != nil {
+		return fmt.Errorf("creating target directory: %w", err)
+	}
+
+	names := make(map[string]interface{}, len(samples))
+
+	for _, sample := range samples {
+		fileName, err := g.fileName(r, sample)
+		if err != nil {
+			return err
+		}
+
+		if prev, found := names[fileName]; found {
+			return fmt.Errorf("%T: file name %s is already used by %T", sample, fileName, prev)
+		}
+
+		names[fileName] = sample
+
+		s, err := r.Reflect(sample, g.ReflectOptions...)
+		if err != nil {
+			return fmt.Errorf("reflecting %T: %w", sample, err)
+		}
+
+		data, err := g.marshal(s)
+		if err != nil {
+			return fmt.Errorf("marshaling %T schema: %w", sample, err)
+		}
+
+		if err := writeFile(filepath.Join(g.Dir, fileName), data); err != nil {
+			return fmt.Errorf("writing %T schema: %w", sample, err)
+		}
+	}
+
+	return nil
+}
+
+func (g Generator) fileName(r *jsonschema.Reflector, sample interface{}) (string, error) {
+	options := append(append([]func(rc *jsonschema.ReflectContext){}, g.ReflectOptions...), jsonschema.RootRef)
+
+	s, err := r.Reflect(sample, options...)
+	if err != nil {
+		return "", fmt.Errorf("reflecting %T: %w", sample, err)
+	}
+
+	defName := ""
+	if s.Ref != nil {
+		defName = (*s.Ref)[strings.LastIndex(*s.Ref, "/")+1:]
+	}
+
+	if g.FileName != nil {
+		return g.FileName(sample, defName), nil
+	}
+
+	if defName == "" {
+		return "", fmt.Errorf("%T: type is not named, FileName is required", sample)
+	}
+
+	return defName + ".json", nil
+}
+
+func (g Generator) marshal(s jsonschema.Schema) ([]byte, error) {
+	indent := g.Indent
+	if indent == "" {
+		indent = "  "
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := json.Indent(buf, data, "", indent); err != nil {
+		return nil, err
+	}
+
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+func writeFile(fn string, data []byte) error {
+	existing, err := os.ReadFile(fn) //nolint:gosec // File name is controlled by generator.
+	if err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return os.WriteFile(fn, data, 0o644) //nolint:gosec // Schema files are not sensitive.
+}
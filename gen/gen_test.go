@@ -0,0 +1,88 @@
+package gen_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/jsonschema-go/gen"
+)
+
+type Item struct {
+	Name string `json:"name" required:"true"`
+}
+
+type Order struct {
+	ID    int    `json:"id" minimum:"1"`
+	Items []Item `json:"items"`
+}
+
+func TestGenerator_Write(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "schemas")
+
+	g := gen.Generator{
+		Dir:            dir,
+		ReflectOptions: []func(rc *jsonschema.ReflectContext){jsonschema.StripDefinitionNamePrefix("Gen")},
+	}
+
+	require.NoError(t, g.Write(Order{}, Item{}))
+
+	order, err := os.ReadFile(filepath.Join(dir, "TestOrder.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `{
+  "definitions": {
+    "TestItem": {
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "name": {
+          "type": "string"
+        }
+      },
+      "type": "object"
+    }
+  },
+  "properties": {
+    "id": {
+      "minimum": 1,
+      "type": "integer"
+    },
+    "items": {
+      "items": {
+        "$ref": "#/definitions/TestItem"
+      },
+      "type": [
+        "array",
+        "null"
+      ]
+    }
+  },
+  "type": "object"
+}
+`, string(order))
+
+	item := filepath.Join(dir, "TestItem.json")
+
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(item, old, old))
+
+	require.NoError(t, g.Write(Item{}))
+
+	fi, err := os.Stat(item)
+	require.NoError(t, err)
+	assert.True(t, fi.ModTime().Equal(old), "unchanged file should not be rewritten")
+
+	assert.EqualError(t, g.Write([]int{}), "[]int: type is not named, FileName is required")
+
+	g.FileName = func(_ interface{}, _ string) string {
+		return "same.json"
+	}
+
+	assert.EqualError(t, g.Write(Item{}, Order{}), "gen_test.Order: file name same.json is already used by gen_test.Item")
+}
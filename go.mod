@@ -8,6 +8,7 @@ require (
 	github.com/swaggest/assertjson v1.9.0
 	github.com/swaggest/refl v1.3.0
 	github.com/yudai/gojsondiff v1.0.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -22,5 +23,4 @@ require (
 	golang.org/x/net v0.7.0 // indirect
 	golang.org/x/sys v0.5.0 // indirect
 	golang.org/x/text v0.13.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,57 @@
+package jsonschema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Hash returns a stable SHA-256 hex digest of schema content, it can be used to detect changes.
+//
+// Hash is computed from canonical JSON form of schema: object keys are sorted (independently of map ordering),
+// types and required properties are sorted, and single type in a list is the same as a plain type.
+// ReflectType and Parent do not affect hash.
+func (s Schema) Hash() (string, error) {
+	c := s.Clone()
+	c.canonicalize()
+
+	j, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("marshal schema: %w", err)
+	}
+
+	sum := sha256.Sum256(j)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalize sorts unordered lists of schema and its subschemas, schema must be a clone.
+func (s *Schema) canonicalize() {
+	sort.Strings(s.Required)
+
+	if s.Type != nil {
+		types := s.simpleTypes()
+
+		if len(types) == 1 {
+			s.Type = &Type{SimpleTypes: &types[0]}
+		} else {
+			sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+		}
+	}
+
+	for _, d := range s.Dependencies {
+		sort.Strings(d.StringArray)
+	}
+
+	s.eachSubSchema(func(_ string, sub *Schema) {
+		sub.canonicalize()
+	})
+
+	for _, def := range s.Definitions {
+		if def.TypeObject != nil {
+			def.TypeObject.canonicalize()
+		}
+	}
+}
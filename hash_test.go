@@ -0,0 +1,49 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestSchema_Hash(t *testing.T) {
+	var s1, s2, s3 jsonschema.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "type":["object","null"],"required":["a","b"],
+	  "properties":{"a":{"type":["string"]},"b":{"type":"integer","x-foo":{"b":1,"a":2}}},
+	  "definitions":{"C":{"type":["null","string"]}}
+	}`), &s1))
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "definitions":{"C":{"type":["string","null"]}},
+	  "properties":{"b":{"x-foo":{"a":2,"b":1},"type":"integer"},"a":{"type":"string"}},
+	  "required":["b","a"],"type":["null","object"]
+	}`), &s2))
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "type":["object","null"],"required":["a","b"],
+	  "properties":{"a":{"type":["string"]},"b":{"type":"number","x-foo":{"b":1,"a":2}}},
+	  "definitions":{"C":{"type":["null","string"]}}
+	}`), &s3))
+
+	h1, err := s1.Hash()
+	require.NoError(t, err)
+	assert.Len(t, h1, 64)
+
+	h2, err := s2.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2)
+
+	h3, err := s3.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, h1, h3)
+
+	// Hashing does not change schema.
+	assert.Equal(t, []string{"b", "a"}, s2.Required)
+	assert.Equal(t, []jsonschema.SimpleType{"string", "null"},
+		s2.Definitions["C"].TypeObject.Type.SliceOfSimpleTypeValues)
+}
@@ -1,14 +1,25 @@
 package jsonschema
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 const (
 	// XEnumNames is the name of JSON property to store names of enumerated values.
 	XEnumNames = "x-enum-names"
+
+	// XEnumDescriptions is the name of JSON property to store descriptions of enumerated values.
+	XEnumDescriptions = "x-enum-descriptions"
+
+	// XDeprecatedReason is the default name of JSON property to store reason of deprecation.
+	XDeprecatedReason = "x-deprecated-reason"
 )
 
 // NamedEnum returns the enumerated acceptable values with according string names.
@@ -16,31 +27,97 @@ type NamedEnum interface {
 	NamedEnum() ([]interface{}, []string)
 }
 
+// DescribedEnum returns the enumerated acceptable values with according string names and descriptions.
+type DescribedEnum interface {
+	DescribedEnum() (values []interface{}, names []string, descriptions []string)
+}
+
 // Enum returns the enumerated acceptable values.
 type Enum interface {
 	Enum() []interface{}
 }
 
+// ConstExposer exposes constant value.
+type ConstExposer interface {
+	JSONSchemaConst() interface{}
+}
+
+// Deprecated exposes deprecation status.
+//
+// Schema of a type that returns true is marked with `"deprecated":true`.
+type Deprecated interface {
+	JSONSchemaDeprecated() bool
+}
+
+// RequiredExposer exposes names of required properties.
+type RequiredExposer interface {
+	JSONSchemaRequired() []string
+}
+
+// ExtrasExposer exposes vendor extensions (e.g. "x-*" properties) to merge into Schema.ExtraProperties.
+type ExtrasExposer interface {
+	JSONSchemaExtras() map[string]interface{}
+}
+
 // Preparer alters reflected JSON Schema.
 type Preparer interface {
 	PrepareJSONSchema(schema *Schema) error
 }
 
+// PropertyPreparer alters reflected JSON Schema of a property of the type.
+//
+// It is invoked for every struct field of the type, after field tags are applied,
+// so that property can be customized separately from the shared definition of the type.
+type PropertyPreparer interface {
+	PrepareJSONSchemaProperty(field reflect.StructField, propertySchema, parent *Schema) error
+}
+
 // Exposer exposes JSON Schema.
 type Exposer interface {
 	JSONSchema() (Schema, error)
 }
 
+// ReflectFunc reflects a sample value into JSON Schema within current reflection context.
+//
+// Named types of reflected values are added as shared definitions and referenced.
+type ReflectFunc func(sample interface{}) (Schema, error)
+
+// ReflectingExposer exposes JSON Schema that can include reflected schemas of other values.
+type ReflectingExposer interface {
+	JSONSchemaWith(reflect ReflectFunc) (Schema, error)
+}
+
 // RawExposer exposes JSON Schema as JSON bytes.
 type RawExposer interface {
 	JSONSchemaBytes() ([]byte, error)
 }
 
+// SchemaFileExposer exposes JSON Schema from a JSON file, e.g. embedded with `//go:embed schema.json`.
+//
+// File is read and parsed once per type and then cached by Reflector.
+type SchemaFileExposer interface {
+	JSONSchemaFile() (fsys fs.FS, name string)
+}
+
 // OneOfExposer exposes "oneOf" items as list of samples.
 type OneOfExposer interface {
 	JSONSchemaOneOf() []interface{}
 }
 
+// DiscriminatedOneOfExposer exposes OpenAPI-style "discriminator" of "oneOf" items.
+//
+// Mapping contains samples of "oneOf" items by discriminator property values.
+// If type does not implement OneOfExposer, "oneOf" is populated from mapping samples.
+type DiscriminatedOneOfExposer interface {
+	JSONSchemaDiscriminator() (propertyName string, mapping map[string]interface{})
+}
+
+// Discriminator is an OpenAPI discriminator object.
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
 // AnyOfExposer exposes "anyOf" items as list of samples.
 type AnyOfExposer interface {
 	JSONSchemaAnyOf() []interface{}
@@ -51,6 +128,18 @@ type AllOfExposer interface {
 	JSONSchemaAllOf() []interface{}
 }
 
+// DependentSchemasExposer exposes dependent schemas as a map of property names to samples.
+//
+// Schemas are reflected into draft-07 "dependencies" keyword, that is equivalent to "dependentSchemas" of newer drafts.
+type DependentSchemasExposer interface {
+	JSONSchemaDependentSchemas() map[string]interface{}
+}
+
+// PatternPropertiesExposer exposes "patternProperties" as a map of regular expressions to samples.
+type PatternPropertiesExposer interface {
+	JSONSchemaPatternProperties() map[string]interface{}
+}
+
 // NotExposer exposes "not" schema as a sample.
 type NotExposer interface {
 	JSONSchemaNot() interface{}
@@ -66,6 +155,13 @@ type ThenExposer interface {
 	JSONSchemaThen() interface{}
 }
 
+// ConditionalExposer exposes "if", "then" and "else" schemas as samples.
+//
+// Nil samples are skipped.
+type ConditionalExposer interface {
+	JSONSchemaIfThenElse() (ifv, thenv, elsev interface{})
+}
+
 // ElseExposer exposes "else" schema as a sample.
 type ElseExposer interface {
 	JSONSchemaElse() interface{}
@@ -173,6 +269,91 @@ func (s *Schema) AddType(t SimpleType) {
 	}
 }
 
+// WithObjectProperty sets property schema configured with a function.
+//
+// Existing property schema is passed to the function to allow incremental changes.
+func (s *Schema) WithObjectProperty(name string, configure func(p *Schema)) *Schema {
+	p := &Schema{}
+
+	if existing, found := s.Properties[name]; found && existing.TypeObject != nil {
+		p = existing.TypeObject
+	}
+
+	if configure != nil {
+		configure(p)
+	}
+
+	return s.WithPropertiesItem(name, p.ToSchemaOrBool())
+}
+
+// WithArrayItems sets items schema configured with a function.
+//
+// Existing items schema is passed to the function to allow incremental changes.
+func (s *Schema) WithArrayItems(configure func(items *Schema)) *Schema {
+	items := &Schema{}
+
+	if s.Items != nil && s.Items.SchemaOrBool != nil && s.Items.SchemaOrBool.TypeObject != nil {
+		items = s.Items.SchemaOrBool.TypeObject
+	}
+
+	if configure != nil {
+		configure(items)
+	}
+
+	s.ItemsEns().SchemaArray = nil
+	s.Items.WithSchemaOrBool(items.ToSchemaOrBool())
+
+	return s
+}
+
+// WithoutProperties returns a copy of Schema without listed properties, required list is adjusted accordingly.
+func (s Schema) WithoutProperties(names ...string) Schema {
+	skip := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		skip[name] = true
+	}
+
+	return s.projectProperties(func(name string) bool {
+		return !skip[name]
+	})
+}
+
+// OnlyProperties returns a copy of Schema with listed properties only, required list is adjusted accordingly.
+func (s Schema) OnlyProperties(names ...string) Schema {
+	keep := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		keep[name] = true
+	}
+
+	return s.projectProperties(func(name string) bool {
+		return keep[name]
+	})
+}
+
+func (s Schema) projectProperties(keep func(name string) bool) Schema {
+	c := s.Clone()
+
+	for name := range c.Properties {
+		if !keep(name) {
+			delete(c.Properties, name)
+		}
+	}
+
+	var required []string
+
+	for _, name := range c.Required {
+		if keep(name) {
+			required = append(required, name)
+		}
+	}
+
+	c.Required = required
+
+	return c
+}
+
 // IsTrivial is true if schema does not contain validation constraints other than type.
 func (s SchemaOrBool) IsTrivial(refResolvers ...func(string) (SchemaOrBool, bool)) bool {
 	if s.TypeBoolean != nil && !*s.TypeBoolean {
@@ -192,7 +373,7 @@ func (s SchemaOrBool) IsTrivial(refResolvers ...func(string) (SchemaOrBool, bool
 // This flag can be used to skip validation of structures that check types during decoding.
 func (s Schema) IsTrivial(refResolvers ...func(string) (SchemaOrBool, bool)) bool {
 	if len(s.AllOf) > 0 || len(s.AnyOf) > 0 || len(s.OneOf) > 0 || s.Not != nil ||
-		s.If != nil || s.Then != nil || s.Else != nil {
+		s.If != nil || s.Then != nil || s.Else != nil || s.DynamicRef != nil || s.RecursiveRef != nil {
 		return false
 	}
 
@@ -219,7 +400,7 @@ func (s Schema) IsTrivial(refResolvers ...func(string) (SchemaOrBool, bool)) boo
 		return false
 	}
 
-	if s.MinItems != 0 || s.MaxItems != nil || s.UniqueItems != nil || s.Contains != nil {
+	if s.MinItems != 0 || s.MaxItems != nil || s.UniqueItems != nil || s.Contains != nil || s.MinContains != nil {
 		return false
 	}
 
@@ -227,7 +408,8 @@ func (s Schema) IsTrivial(refResolvers ...func(string) (SchemaOrBool, bool)) boo
 		return false
 	}
 
-	if len(s.Dependencies) > 0 || s.PropertyNames != nil || s.Const != nil || len(s.Enum) > 0 {
+	if len(s.Dependencies) > 0 || len(s.DependentRequired) > 0 || s.PropertyNames != nil ||
+		s.UnevaluatedProperties != nil || s.Const != nil || len(s.Enum) > 0 {
 		return false
 	}
 
@@ -287,6 +469,69 @@ func (s Schema) IsTrivial(refResolvers ...func(string) (SchemaOrBool, bool)) boo
 	return resolved
 }
 
+// ResolveRef finds schema by local reference, e.g. "#/definitions/Foo" or "#" for the Schema itself.
+//
+// Plain name fragment, e.g. "#foo", refers to a subschema or definition with matching $anchor or $dynamicAnchor.
+// Definitions that are references themselves are followed, false is returned on unknown or cyclic reference.
+func (s *Schema) ResolveRef(ref string) (*Schema, bool) {
+	seen := map[string]bool{}
+
+	for {
+		if seen[ref] {
+			return nil, false
+		}
+
+		seen[ref] = true
+
+		if ref == "#" {
+			return s, true
+		}
+
+		var target *Schema
+
+		if strings.HasPrefix(ref, "#") && !strings.HasPrefix(ref, "#/") {
+			target = s.findAnchor(ref[1:])
+		} else if name, ok := definitionName(ref); ok {
+			if def, found := s.Definitions[name]; found {
+				target = def.TypeObject
+			}
+		}
+
+		if target == nil {
+			return nil, false
+		}
+
+		if target.Ref == nil {
+			return target, true
+		}
+
+		ref = *target.Ref
+	}
+}
+
+// findAnchor returns schema, its definition or subschema that declares $anchor or $dynamicAnchor with given name.
+func (s *Schema) findAnchor(name string) *Schema {
+	if (s.Anchor != nil && *s.Anchor == name) || (s.DynamicAnchor != nil && *s.DynamicAnchor == name) {
+		return s
+	}
+
+	var found *Schema
+
+	for _, def := range s.Definitions {
+		if def.TypeObject != nil && found == nil {
+			found = def.TypeObject.findAnchor(name)
+		}
+	}
+
+	s.eachSubSchema(func(_ string, sub *Schema) {
+		if found == nil {
+			found = sub.findAnchor(name)
+		}
+	})
+
+	return found
+}
+
 // HasType checks if Schema has a simple type.
 func (s *Schema) HasType(t SimpleType) bool {
 	if s.Type == nil {
@@ -356,3 +601,697 @@ func (s *SchemaOrBool) FromSimpleMap(m map[string]interface{}) error {
 
 	return json.Unmarshal(j, s.TypeObjectEns())
 }
+
+// ToSimpleMap encodes JSON Schema as a map.
+func (s Schema) ToSimpleMap() (map[string]interface{}, error) {
+	return s.ToSchemaOrBool().ToSimpleMap()
+}
+
+// FromSimpleMap decodes JSON Schema from a map.
+func (s *Schema) FromSimpleMap(m map[string]interface{}) error {
+	j, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	*s = Schema{}
+
+	return json.Unmarshal(j, s)
+}
+
+// mergeSchema copies non-empty values of src into dst, extra properties are merged by keys.
+func mergeSchema(dst *Schema, src Schema) {
+	dv := reflect.ValueOf(dst).Elem()
+	sv := reflect.ValueOf(src)
+
+	for i := 0; i < sv.NumField(); i++ {
+		switch dv.Type().Field(i).Name {
+		case "ExtraProperties", "ReflectType", "Parent":
+			continue
+		}
+
+		if f := sv.Field(i); !f.IsZero() {
+			dv.Field(i).Set(f)
+		}
+	}
+
+	dst.WithExtraPropertiesItems(src.ExtraProperties)
+}
+
+// WithExtraPropertiesItems merges items into ExtraProperties, existing keys are overwritten.
+func (s *Schema) WithExtraPropertiesItems(items map[string]interface{}) *Schema {
+	for k, v := range items {
+		s.WithExtraPropertiesItem(k, v)
+	}
+
+	return s
+}
+
+// ExtraString returns string value of extra property, false is returned if property is missing or not a string.
+func (s Schema) ExtraString(key string) (string, bool) {
+	v := reflect.ValueOf(s.ExtraProperties[key])
+	if v.Kind() != reflect.String {
+		return "", false
+	}
+
+	return v.String(), true
+}
+
+// ExtraBool returns boolean value of extra property, false is returned if property is missing or not a boolean.
+func (s Schema) ExtraBool(key string) (value, ok bool) {
+	v := reflect.ValueOf(s.ExtraProperties[key])
+	if v.Kind() != reflect.Bool {
+		return false, false
+	}
+
+	return v.Bool(), true
+}
+
+// ExtraSlice returns items of extra property, false is returned if property is missing or not a slice or array.
+//
+// Typed slices (e.g. []string set by reflection) and []interface{} (e.g. after JSON decoding) are both supported.
+func (s Schema) ExtraSlice(key string) ([]interface{}, bool) {
+	v := reflect.ValueOf(s.ExtraProperties[key])
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	if items, ok := v.Interface().([]interface{}); ok {
+		return items, true
+	}
+
+	items := make([]interface{}, v.Len())
+
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+
+	return items, true
+}
+
+// Clone returns a deep copy of SchemaOrBool.
+func (s SchemaOrBool) Clone() SchemaOrBool {
+	c := SchemaOrBool{}
+
+	if s.TypeObject != nil {
+		so := s.TypeObject.Clone()
+		c.TypeObject = &so
+	}
+
+	c.TypeBoolean = cloneBool(s.TypeBoolean)
+
+	return c
+}
+
+// Clone returns a deep copy of Schema.
+//
+// Properties, items, definitions and other nested schemas are copied recursively,
+// slices and maps of JSON values (e.g. enum, default, extra properties) are also copied.
+// ReflectType and Parent are not copied deeply and refer to the same values as original.
+func (s Schema) Clone() Schema {
+	c := s
+
+	c.ID = cloneString(s.ID)
+	c.Schema = cloneString(s.Schema)
+	c.Ref = cloneString(s.Ref)
+	c.Comment = cloneString(s.Comment)
+	c.Anchor = cloneString(s.Anchor)
+	c.DynamicAnchor = cloneString(s.DynamicAnchor)
+	c.DynamicRef = cloneString(s.DynamicRef)
+	c.RecursiveRef = cloneString(s.RecursiveRef)
+	c.Title = cloneString(s.Title)
+	c.Description = cloneString(s.Description)
+	c.ReadOnly = cloneBool(s.ReadOnly)
+	c.MultipleOf = cloneFloat(s.MultipleOf)
+	c.Maximum = cloneFloat(s.Maximum)
+	c.ExclusiveMaximum = cloneFloat(s.ExclusiveMaximum)
+	c.Minimum = cloneFloat(s.Minimum)
+	c.ExclusiveMinimum = cloneFloat(s.ExclusiveMinimum)
+	c.MaxLength = cloneInt(s.MaxLength)
+	c.Pattern = cloneString(s.Pattern)
+	c.MaxItems = cloneInt(s.MaxItems)
+	c.MinContains = cloneInt(s.MinContains)
+	c.UniqueItems = cloneBool(s.UniqueItems)
+	c.MaxProperties = cloneInt(s.MaxProperties)
+	c.Format = cloneString(s.Format)
+	c.ContentMediaType = cloneString(s.ContentMediaType)
+	c.ContentEncoding = cloneString(s.ContentEncoding)
+
+	if s.Default != nil {
+		c.WithDefault(cloneValue(*s.Default))
+	}
+
+	if s.Const != nil {
+		c.WithConst(cloneValue(*s.Const))
+	}
+
+	c.Examples = cloneValues(s.Examples)
+	c.Enum = cloneValues(s.Enum)
+
+	if s.Required != nil {
+		c.Required = append([]string{}, s.Required...)
+	}
+
+	if s.Type != nil {
+		t := Type{}
+
+		if s.Type.SimpleTypes != nil {
+			t.WithSimpleTypes(*s.Type.SimpleTypes)
+		}
+
+		if s.Type.SliceOfSimpleTypeValues != nil {
+			t.SliceOfSimpleTypeValues = append([]SimpleType{}, s.Type.SliceOfSimpleTypeValues...)
+		}
+
+		c.Type = &t
+	}
+
+	if s.Items != nil {
+		i := Items{
+			SchemaOrBool: cloneSchemaOrBoolPtr(s.Items.SchemaOrBool),
+			SchemaArray:  cloneSchemaOrBools(s.Items.SchemaArray),
+		}
+		c.Items = &i
+	}
+
+	c.AdditionalItems = cloneSchemaOrBoolPtr(s.AdditionalItems)
+	c.Contains = cloneSchemaOrBoolPtr(s.Contains)
+	c.AdditionalProperties = cloneSchemaOrBoolPtr(s.AdditionalProperties)
+	c.PropertyNames = cloneSchemaOrBoolPtr(s.PropertyNames)
+	c.If = cloneSchemaOrBoolPtr(s.If)
+	c.Then = cloneSchemaOrBoolPtr(s.Then)
+	c.Else = cloneSchemaOrBoolPtr(s.Else)
+	c.Not = cloneSchemaOrBoolPtr(s.Not)
+	c.UnevaluatedProperties = cloneSchemaOrBoolPtr(s.UnevaluatedProperties)
+
+	c.AllOf = cloneSchemaOrBools(s.AllOf)
+	c.AnyOf = cloneSchemaOrBools(s.AnyOf)
+	c.OneOf = cloneSchemaOrBools(s.OneOf)
+
+	c.Definitions = cloneSchemaOrBoolMap(s.Definitions)
+	c.Properties = cloneSchemaOrBoolMap(s.Properties)
+	c.PatternProperties = cloneSchemaOrBoolMap(s.PatternProperties)
+
+	if s.Dependencies != nil {
+		c.Dependencies = make(map[string]DependenciesAdditionalProperties, len(s.Dependencies))
+
+		for k, d := range s.Dependencies {
+			cd := DependenciesAdditionalProperties{SchemaOrBool: cloneSchemaOrBoolPtr(d.SchemaOrBool)}
+
+			if d.StringArray != nil {
+				cd.StringArray = append([]string{}, d.StringArray...)
+			}
+
+			c.Dependencies[k] = cd
+		}
+	}
+
+	if s.DependentRequired != nil {
+		c.DependentRequired = make(map[string][]string, len(s.DependentRequired))
+
+		for k, r := range s.DependentRequired {
+			c.DependentRequired[k] = append([]string{}, r...)
+		}
+	}
+
+	if s.ExtraProperties != nil {
+		c.ExtraProperties = make(map[string]interface{}, len(s.ExtraProperties))
+
+		for k, v := range s.ExtraProperties {
+			c.ExtraProperties[k] = cloneValue(v)
+		}
+	}
+
+	return c
+}
+
+func cloneString(v *string) *string {
+	if v == nil {
+		return nil
+	}
+
+	c := *v
+
+	return &c
+}
+
+func cloneBool(v *bool) *bool {
+	if v == nil {
+		return nil
+	}
+
+	c := *v
+
+	return &c
+}
+
+func cloneFloat(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+
+	c := *v
+
+	return &c
+}
+
+func cloneInt(v *int64) *int64 {
+	if v == nil {
+		return nil
+	}
+
+	c := *v
+
+	return &c
+}
+
+func cloneSchemaOrBoolPtr(s *SchemaOrBool) *SchemaOrBool {
+	if s == nil {
+		return nil
+	}
+
+	c := s.Clone()
+
+	return &c
+}
+
+func cloneSchemaOrBools(items []SchemaOrBool) []SchemaOrBool {
+	if items == nil {
+		return nil
+	}
+
+	c := make([]SchemaOrBool, len(items))
+
+	for i, item := range items {
+		c[i] = item.Clone()
+	}
+
+	return c
+}
+
+func cloneSchemaOrBoolMap(m map[string]SchemaOrBool) map[string]SchemaOrBool {
+	if m == nil {
+		return nil
+	}
+
+	c := make(map[string]SchemaOrBool, len(m))
+
+	for k, v := range m {
+		c[k] = v.Clone()
+	}
+
+	return c
+}
+
+func cloneValues(values []interface{}) []interface{} {
+	if values == nil {
+		return nil
+	}
+
+	c := make([]interface{}, len(values))
+
+	for i, v := range values {
+		c[i] = cloneValue(v)
+	}
+
+	return c
+}
+
+// cloneValue copies JSON-like values, other values are returned as is.
+func cloneValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case []interface{}:
+		return cloneValues(vv)
+	case map[string]interface{}:
+		if vv == nil {
+			return vv
+		}
+
+		c := make(map[string]interface{}, len(vv))
+
+		for k, val := range vv {
+			c[k] = cloneValue(val)
+		}
+
+		return c
+	default:
+		return cloneReflectValue(v)
+	}
+}
+
+// cloneReflectValue copies typed slices and maps (e.g. []string of enum names), other values are returned as is.
+func cloneReflectValue(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+
+	//nolint:exhaustive // Other kinds are returned as is.
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return v
+		}
+
+		c := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		reflect.Copy(c, rv)
+
+		return c.Interface()
+	case reflect.Map:
+		if rv.IsNil() {
+			return v
+		}
+
+		c := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), iter.Value())
+		}
+
+		return c.Interface()
+	default:
+		return v
+	}
+}
+
+// Equal checks if schemas have the same content.
+//
+// Nil and empty slices or maps are considered equal, as well as numeric values of different Go types,
+// order of types and required properties is ignored.
+// ReflectType and Parent are not compared.
+func (s Schema) Equal(other Schema) bool {
+	return equalValue(reflect.ValueOf(s), reflect.ValueOf(other))
+}
+
+// Equal checks if schemas have the same content, see Schema.Equal.
+func (s SchemaOrBool) Equal(other SchemaOrBool) bool {
+	return equalValue(reflect.ValueOf(s), reflect.ValueOf(other))
+}
+
+func (s Schema) simpleTypes() []SimpleType {
+	if s.Type == nil {
+		return nil
+	}
+
+	if s.Type.SimpleTypes != nil {
+		return []SimpleType{*s.Type.SimpleTypes}
+	}
+
+	return s.Type.SliceOfSimpleTypeValues
+}
+
+func equalSchema(a, b Schema) bool {
+	at, bt := a.simpleTypes(), b.simpleTypes()
+	if len(at) != len(bt) {
+		return false
+	}
+
+	for _, t := range at {
+		if !b.HasType(t) {
+			return false
+		}
+	}
+
+	if !equalStringSet(a.Required, b.Required) {
+		return false
+	}
+
+	if !equalValue(reflect.ValueOf(a.ExtraProperties), reflect.ValueOf(b.ExtraProperties)) {
+		return false
+	}
+
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	for i := 0; i < av.NumField(); i++ {
+		switch av.Type().Field(i).Name {
+		case "Type", "Required", "ExtraProperties", "ReflectType", "Parent":
+			continue
+		}
+
+		if !equalValue(av.Field(i), bv.Field(i)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalStringSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	seen := make(map[string]int, len(a))
+
+	for _, v := range a {
+		seen[v]++
+	}
+
+	for _, v := range b {
+		if seen[v] == 0 {
+			return false
+		}
+
+		seen[v]--
+	}
+
+	return true
+}
+
+func equalValue(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+
+	if b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+
+	if af, ok := numberValue(a); ok {
+		bf, ok := numberValue(b)
+
+		return ok && af == bf
+	}
+
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	//nolint:exhaustive // Other kinds are compared with reflect.DeepEqual.
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+
+		return equalValue(a.Elem(), b.Elem())
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+
+		for i := 0; i < a.Len(); i++ {
+			if !equalValue(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+
+		iter := a.MapRange()
+		for iter.Next() {
+			bv := b.MapIndex(iter.Key())
+			if !bv.IsValid() || !equalValue(iter.Value(), bv) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Struct:
+		if as, ok := a.Interface().(Schema); ok {
+			return equalSchema(as, b.Interface().(Schema))
+		}
+
+		for i := 0; i < a.NumField(); i++ {
+			// Unexported fields (e.g. of time.Time) can not be accessed, JSON encodings are compared instead.
+			if !a.Field(i).CanInterface() {
+				return equalJSON(a, b)
+			}
+		}
+
+		for i := 0; i < a.NumField(); i++ {
+			if !equalValue(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
+
+func equalJSON(a, b reflect.Value) bool {
+	aj, err := json.Marshal(a.Interface())
+	if err != nil {
+		return false
+	}
+
+	bj, err := json.Marshal(b.Interface())
+
+	return err == nil && bytes.Equal(aj, bj)
+}
+
+func numberValue(v reflect.Value) (float64, bool) {
+	if n, ok := v.Interface().(json.Number); ok {
+		f, err := n.Float64()
+
+		return f, err == nil
+	}
+
+	//nolint:exhaustive // Only numeric kinds are relevant.
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// definitionName returns unescaped name of local definition reference.
+func definitionName(ref string) (string, bool) {
+	if !strings.HasPrefix(ref, "#/definitions/") {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(ref, "#/definitions/")
+
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~1", "/"), "~0", "~"), true
+}
+
+// ReachableDefinitions returns sorted names of definitions that are reachable from the Schema by local references.
+//
+// References in subschemas of extra properties (e.g. "$defs" or vendor extensions) are also followed.
+func (s Schema) ReachableDefinitions() []string {
+	used := s.reachableDefinitions()
+	names := make([]string, 0, len(used))
+
+	for name := range used {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (s *Schema) reachableDefinitions() map[string]bool {
+	used := map[string]bool{}
+
+	if len(s.Definitions) == 0 {
+		return used
+	}
+
+	var visit func(sc *Schema)
+
+	visit = func(sc *Schema) {
+		refs := map[string]bool{}
+		collectRefs(sc, refs)
+
+		for ref := range refs {
+			name, ok := definitionName(ref)
+
+			if def, found := s.Definitions[name]; ok && found && !used[name] {
+				used[name] = true
+
+				if def.TypeObject != nil {
+					visit(def.TypeObject)
+				}
+			}
+		}
+	}
+
+	visit(s)
+
+	return used
+}
+
+// PruneUnusedDefinitions removes definitions that are not reachable from the Schema by local references.
+func (s *Schema) PruneUnusedDefinitions() {
+	if len(s.Definitions) == 0 {
+		return
+	}
+
+	used := s.reachableDefinitions()
+
+	for name := range s.Definitions {
+		if !used[name] {
+			delete(s.Definitions, name)
+		}
+	}
+
+	if len(s.Definitions) == 0 {
+		s.Definitions = nil
+	}
+}
+
+// eachSubSchema calls f for every direct subschema except definitions, ptr is a relative JSON Pointer of subschema.
+func (s *Schema) eachSubSchema(f func(ptr string, sub *Schema)) {
+	visit := func(ptr string, sb *SchemaOrBool) {
+		if sb != nil && sb.TypeObject != nil {
+			f(ptr, sb.TypeObject)
+		}
+	}
+
+	visitAll := func(ptr string, items []SchemaOrBool) {
+		for i := range items {
+			visit(ptr+"/"+strconv.Itoa(i), &items[i])
+		}
+	}
+
+	visitMap := func(ptr string, m map[string]SchemaOrBool) {
+		for name, sb := range m {
+			sb := sb
+			visit(ptr+"/"+escapePointer(name), &sb)
+		}
+	}
+
+	if s.Items != nil {
+		visit("/items", s.Items.SchemaOrBool)
+		visitAll("/items", s.Items.SchemaArray)
+	}
+
+	visit("/additionalItems", s.AdditionalItems)
+	visit("/contains", s.Contains)
+	visit("/additionalProperties", s.AdditionalProperties)
+	visit("/propertyNames", s.PropertyNames)
+	visit("/if", s.If)
+	visit("/then", s.Then)
+	visit("/else", s.Else)
+	visit("/not", s.Not)
+	visit("/unevaluatedProperties", s.UnevaluatedProperties)
+	visitAll("/allOf", s.AllOf)
+	visitAll("/anyOf", s.AnyOf)
+	visitAll("/oneOf", s.OneOf)
+	visitMap("/properties", s.Properties)
+	visitMap("/patternProperties", s.PatternProperties)
+
+	for name, d := range s.Dependencies {
+		visit("/dependencies/"+escapePointer(name), d.SchemaOrBool)
+	}
+}
+
+// escapePointer escapes JSON Pointer reference token.
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
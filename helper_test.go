@@ -2,11 +2,12 @@ package jsonschema_test
 
 import (
 	"encoding/json"
-	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
 	"github.com/swaggest/jsonschema-go"
 )
 
@@ -47,6 +48,30 @@ func TestSchemaOrBool_JSONSchemaBytes(t *testing.T) {
 	assert.Equal(t, map[string]interface{}{}, m)
 }
 
+func TestSchema_ToSimpleMap(t *testing.T) {
+	s := jsonschema.Schema{}
+	s.AddType(jsonschema.Object)
+	s.WithPropertiesItem("foo", jsonschema.String.ToSchemaOrBool())
+	s.WithExtraPropertiesItem("x-foo", "bar")
+
+	m, err := s.ToSimpleMap()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"foo": map[string]interface{}{"type": "string"}},
+		"x-foo":      "bar",
+	}, m)
+
+	var s2 jsonschema.Schema
+
+	s2.WithTitle("overwritten")
+	require.NoError(t, s2.FromSimpleMap(m))
+	assert.Nil(t, s2.Title)
+	assert.True(t, s2.Equal(s))
+
+	assert.Error(t, s2.FromSimpleMap(map[string]interface{}{"type": 123}))
+}
+
 func TestSchema_IsTrivial(t *testing.T) {
 	for _, s := range []struct {
 		isTrivial bool
@@ -117,9 +142,12 @@ func TestSchema_IsTrivial_reflect(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.True(t, s.IsTrivial(func(ref string) (jsonschema.SchemaOrBool, bool) {
-		rs, found := s.Definitions[strings.TrimPrefix(ref, "#/definitions/")]
+		rs, found := s.ResolveRef(ref)
+		if !found {
+			return jsonschema.SchemaOrBool{}, false
+		}
 
-		return rs, found
+		return rs.ToSchemaOrBool(), true
 	}))
 }
 
@@ -144,9 +172,12 @@ func TestSchema_IsTrivial_recursive(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.False(t, s.IsTrivial(func(ref string) (jsonschema.SchemaOrBool, bool) {
-		rs, found := s.Definitions[strings.TrimPrefix(ref, "#/definitions/")]
+		rs, found := s.ResolveRef(ref)
+		if !found {
+			return jsonschema.SchemaOrBool{}, false
+		}
 
-		return rs, found
+		return rs.ToSchemaOrBool(), true
 	}))
 }
 
@@ -171,8 +202,332 @@ func TestSchema_IsTrivial_recursiveTrivial(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.True(t, s.IsTrivial(func(ref string) (jsonschema.SchemaOrBool, bool) {
-		rs, found := s.Definitions[strings.TrimPrefix(ref, "#/definitions/")]
+		rs, found := s.ResolveRef(ref)
+		if !found {
+			return jsonschema.SchemaOrBool{}, false
+		}
 
-		return rs, found
+		return rs.ToSchemaOrBool(), true
 	}))
 }
+
+func TestSchema_Clone(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "title":"orig","type":["object","null"],"required":["a"],"enum":[{"a":[1]}],"default":{"a":[1]},
+	  "properties":{"a":{"type":"array","items":{"type":"integer","minimum":1}}},
+	  "definitions":{"D":{"oneOf":[true,{"const":1}]}},
+	  "dependencies":{"a":["b"]},"x-foo":{"bar":[1]}
+	}`), &s))
+
+	orig, err := json.Marshal(s)
+	require.NoError(t, err)
+
+	c := s.Clone()
+
+	c.WithTitle("cloned")
+	c.Type.SliceOfSimpleTypeValues[0] = jsonschema.String
+	c.Required[0] = "b"
+	c.Enum[0].(map[string]interface{})["a"] = 2
+	(*c.Default).(map[string]interface{})["a"].([]interface{})[0] = 2
+	c.Properties["a"].TypeObject.Items.SchemaOrBool.TypeObject.WithMinimum(2)
+	c.Definitions["D"].TypeObject.OneOf[0].WithTypeBoolean(false)
+	c.Dependencies["a"].StringArray[0] = "c"
+	c.ExtraProperties["x-foo"].(map[string]interface{})["bar"] = 2
+	c.WithPropertiesItem("b", jsonschema.String.ToSchemaOrBool())
+
+	after, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.Equal(t, string(orig), string(after))
+
+	sb := s.ToSchemaOrBool()
+	cb := sb.Clone()
+	cb.TypeObject.WithTitle("cloned")
+	assert.Equal(t, "orig", *s.Title)
+}
+
+func TestSchema_Equal(t *testing.T) {
+	parse := func(s string) jsonschema.Schema {
+		var r jsonschema.Schema
+
+		require.NoError(t, json.Unmarshal([]byte(s), &r))
+
+		return r
+	}
+
+	a := parse(`{"type":["string","null"],"required":["a","b"],"enum":[1,2],"properties":{"a":{"minimum":1}},"x-a":{"b":[1]}}`)
+	b := parse(`{"type":["null","string"],"required":["b","a"],"enum":[1,2],"properties":{"a":{"minimum":1}},"x-a":{"b":[1]}}`)
+
+	assert.True(t, a.Equal(b))
+	assert.True(t, a.Equal(a.Clone()))
+	assert.True(t, a.ToSchemaOrBool().Equal(b.ToSchemaOrBool()))
+
+	b.Properties["a"].TypeObject.WithMinimum(2)
+	assert.False(t, a.Equal(b))
+
+	empty := jsonschema.Schema{}
+	empty.Enum = []interface{}{}
+	empty.Properties = map[string]jsonschema.SchemaOrBool{}
+	assert.True(t, empty.Equal(jsonschema.Schema{}))
+
+	n1 := jsonschema.Schema{}
+	n1.WithEnum(1, int64(2))
+
+	n2 := jsonschema.Schema{}
+	n2.WithEnum(1.0, json.Number("2"))
+
+	assert.True(t, n1.Equal(n2))
+
+	n1.AddType(jsonschema.Integer)
+	assert.False(t, n1.Equal(n2))
+	assert.False(t, jsonschema.String.ToSchemaOrBool().Equal(*(&jsonschema.SchemaOrBool{}).WithTypeBoolean(true)))
+
+	t1 := jsonschema.Schema{}
+	t1.WithDefault(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	t2 := jsonschema.Schema{}
+	t2.WithDefault(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	assert.True(t, t1.Equal(t2))
+
+	t2.WithDefault(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	assert.False(t, t1.Equal(t2))
+}
+
+func TestSchema_ResolveRef(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "title":"root",
+	  "definitions":{
+		"A":{"type":"string"},"B":{"$ref":"#/definitions/A"},"R":{"$ref":"#"},
+		"C1":{"$ref":"#/definitions/C2"},"C2":{"$ref":"#/definitions/C1"},
+		"a/b":{"type":"integer"},"T":true
+	  }
+	}`), &s))
+
+	rs, found := s.ResolveRef("#/definitions/A")
+	require.True(t, found)
+	assert.True(t, rs.HasType(jsonschema.String))
+	assert.Equal(t, s.Definitions["A"].TypeObject, rs)
+
+	rs, found = s.ResolveRef("#/definitions/B")
+	require.True(t, found)
+	assert.True(t, rs.HasType(jsonschema.String))
+
+	rs, found = s.ResolveRef("#/definitions/R")
+	require.True(t, found)
+	assert.Equal(t, &s, rs)
+
+	rs, found = s.ResolveRef("#/definitions/a~1b")
+	require.True(t, found)
+	assert.True(t, rs.HasType(jsonschema.Integer))
+
+	for _, ref := range []string{"#/definitions/C1", "#/definitions/T", "#/definitions/X", "other.json#/definitions/A"} {
+		rs, found = s.ResolveRef(ref)
+		assert.False(t, found, ref)
+		assert.Nil(t, rs, ref)
+	}
+}
+
+func TestSchema_ResolveRef_anchor(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "$dynamicAnchor":"node",
+	  "properties":{"name":{"$anchor":"name","type":"string"},"child":{"$dynamicRef":"#node"}},
+	  "definitions":{"Alias":{"$anchor":"alias","$ref":"#name"},"Loop":{"$anchor":"loop","$ref":"#loop"}}
+	}`), &s))
+
+	rs, found := s.ResolveRef("#name")
+	require.True(t, found)
+	assert.True(t, rs.HasType(jsonschema.String))
+	assert.Equal(t, s.Properties["name"].TypeObject, rs)
+
+	rs, found = s.ResolveRef("#node")
+	require.True(t, found)
+	assert.Equal(t, &s, rs)
+
+	rs, found = s.ResolveRef("#alias")
+	require.True(t, found)
+	assert.True(t, rs.HasType(jsonschema.String))
+
+	for _, ref := range []string{"#loop", "#unknown"} {
+		rs, found = s.ResolveRef(ref)
+		assert.False(t, found, ref)
+		assert.Nil(t, rs, ref)
+	}
+
+	assert.False(t, s.Properties["child"].IsTrivial())
+
+	j, err := json.Marshal(s.Clone())
+	require.NoError(t, err)
+	assertjson.Equal(t, []byte(`{
+	  "$dynamicAnchor":"node",
+	  "properties":{"name":{"$anchor":"name","type":"string"},"child":{"$dynamicRef":"#node"}},
+	  "definitions":{"Alias":{"$anchor":"alias","$ref":"#name"},"Loop":{"$anchor":"loop","$ref":"#loop"}}
+	}`), j)
+}
+
+func TestSchema_PruneUnusedDefinitions(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "definitions":{
+		"A":{"properties":{"b":{"$ref":"#/definitions/B"}}},"B":{"items":{"$ref":"#/definitions/B"}},
+		"C":{"anyOf":[{"$ref":"#/definitions/A"}]},"D":{"type":"string"},"E":{"$ref":"#/definitions/D"}
+	  },
+	  "properties":{"a":{"$ref":"#/definitions/A"}},
+	  "dependencies":{"a":{"not":{"$ref":"#/definitions/E"}}}
+	}`), &s))
+
+	s.PruneUnusedDefinitions()
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"A":{"properties":{"b":{"$ref":"#/definitions/B"}}},"B":{"items":{"$ref":"#/definitions/B"}},
+		"D":{"type":"string"},"E":{"$ref":"#/definitions/D"}
+	  },
+	  "properties":{"a":{"$ref":"#/definitions/A"}},
+	  "dependencies":{"a":{"not":{"$ref":"#/definitions/E"}}}
+	}`, s)
+
+	s.Properties = nil
+	s.Dependencies = nil
+	s.PruneUnusedDefinitions()
+
+	assert.Nil(t, s.Definitions)
+}
+
+func TestSchema_WithObjectProperty(t *testing.T) {
+	s := jsonschema.Schema{}
+	s.AddType(jsonschema.Object)
+
+	s.WithObjectProperty("name", func(p *jsonschema.Schema) {
+		p.AddType(jsonschema.String)
+		p.WithMinLength(1)
+	}).WithObjectProperty("tags", func(p *jsonschema.Schema) {
+		p.AddType(jsonschema.Array)
+		p.WithArrayItems(func(items *jsonschema.Schema) {
+			items.AddType(jsonschema.String)
+		})
+	}).WithObjectProperty("name", func(p *jsonschema.Schema) {
+		p.WithMaxLength(10)
+	}).WithRequired("name")
+
+	s.Properties["tags"].TypeObject.WithArrayItems(func(items *jsonschema.Schema) {
+		items.WithFormat("uuid")
+	})
+
+	assertjson.EqMarshal(t, `{
+	  "required":["name"],
+	  "properties":{
+		"name":{"maxLength":10,"minLength":1,"type":"string"},
+		"tags":{"items":{"format":"uuid","type":"string"},"type":"array"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestSchema_ExtraString(t *testing.T) {
+	type level string
+
+	s := jsonschema.Schema{}
+	s.WithExtraPropertiesItem("x-foo", "bar")
+	s.WithExtraPropertiesItems(map[string]interface{}{
+		"x-level":    level("high"),
+		"x-nullable": true,
+		"x-foo":      "baz",
+	})
+	s.WithExtraPropertiesItem(jsonschema.XEnumNames, []string{"A", "B"})
+
+	v, ok := s.ExtraString("x-foo")
+	assert.True(t, ok)
+	assert.Equal(t, "baz", v)
+
+	v, ok = s.ExtraString("x-level")
+	assert.True(t, ok)
+	assert.Equal(t, "high", v)
+
+	_, ok = s.ExtraString("x-nullable")
+	assert.False(t, ok)
+
+	b, ok := s.ExtraBool("x-nullable")
+	assert.True(t, ok)
+	assert.True(t, b)
+
+	_, ok = s.ExtraBool("x-missing")
+	assert.False(t, ok)
+
+	items, ok := s.ExtraSlice(jsonschema.XEnumNames)
+	assert.True(t, ok)
+	assert.Equal(t, []interface{}{"A", "B"}, items)
+
+	_, ok = s.ExtraSlice("x-foo")
+	assert.False(t, ok)
+
+	// Typed extras are preserved by Clone without sharing.
+	c := s.Clone()
+	c.ExtraProperties[jsonschema.XEnumNames].([]string)[0] = "C"
+
+	items, ok = s.ExtraSlice(jsonschema.XEnumNames)
+	assert.True(t, ok)
+	assert.Equal(t, []interface{}{"A", "B"}, items)
+
+	// Extras decoded from JSON.
+	var d jsonschema.Schema
+
+	require.NoError(t, d.UnmarshalJSON([]byte(`{"x-enum-names":["A","B"],"x-nullable":true}`)))
+
+	items, ok = d.ExtraSlice(jsonschema.XEnumNames)
+	assert.True(t, ok)
+	assert.Equal(t, []interface{}{"A", "B"}, items)
+
+	b, ok = d.ExtraBool("x-nullable")
+	assert.True(t, ok)
+	assert.True(t, b)
+}
+
+func TestSchema_WithoutProperties(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+	  "type":"object","required":["id","email","role"],
+	  "properties":{"id":{"type":"integer"},"email":{"type":"string"},"role":{"type":"string"},"note":{"type":"string"}}
+	}`)))
+
+	assertjson.EqMarshal(t, `{
+	  "required":["id"],"properties":{"id":{"type":"integer"},"note":{"type":"string"}},"type":"object"
+	}`, s.WithoutProperties("email", "role"))
+
+	assertjson.EqMarshal(t, `{
+	  "required":["email","role"],"properties":{"email":{"type":"string"},"role":{"type":"string"}},"type":"object"
+	}`, s.OnlyProperties("email", "role", "missing"))
+
+	assertjson.EqMarshal(t, `{"properties":{"note":{"type":"string"}},"type":"object"}`, s.OnlyProperties("note"))
+
+	// Original schema is not changed.
+	assert.Len(t, s.Properties, 4)
+	assert.Equal(t, []string{"id", "email", "role"}, s.Required)
+}
+
+func TestSchema_ReachableDefinitions(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "definitions":{
+		"A":{"properties":{"b":{"$ref":"#/definitions/B"}}},"B":{"items":{"$ref":"#/definitions/B"}},
+		"C":{"type":"string"},"D":{"type":"string"}
+	  },
+	  "properties":{"a":{"$ref":"#/definitions/A"}}
+	}`), &s))
+
+	assert.Equal(t, []string{"A", "B"}, s.ReachableDefinitions())
+
+	s.ExtraProperties = map[string]interface{}{"x-alt": jsonschema.SchemaOrBool{
+		TypeObject: (&jsonschema.Schema{}).WithRef("#/definitions/C"),
+	}}
+
+	assert.Equal(t, []string{"A", "B", "C"}, s.ReachableDefinitions())
+}
@@ -0,0 +1,213 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// InferJSONMarshalers enables schema inference from JSON output of json.Marshaler implementations.
+//
+// Sample value is marshaled once and resulting JSON is used to build schema instead of reflecting Go fields.
+func InferJSONMarshalers(rc *ReflectContext) {
+	rc.InferJSONMarshalers = true
+}
+
+func checkJSONMarshaler(t reflect.Type, v reflect.Value, schema *Schema) (bool, error) {
+	if t == typeOfJSONRawMsg {
+		return false, nil
+	}
+
+	var m json.Marshaler
+
+	if jm, ok := safeInterface(v).(json.Marshaler); ok {
+		m = jm
+	} else if jm, ok := ptrTo(v).(json.Marshaler); ok {
+		m = jm
+	}
+
+	if m == nil {
+		return false, nil
+	}
+
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return true, fmt.Errorf("marshaling %T sample: %w", m, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var val interface{}
+
+	if err := dec.Decode(&val); err != nil {
+		return true, fmt.Errorf("decoding %T sample: %w", m, err)
+	}
+
+	inferred := inferSchema(val)
+
+	if schema.HasType(Null) {
+		inferred.AddType(Null)
+	}
+
+	inferred.ReflectType = schema.ReflectType
+	inferred.Parent = schema.Parent
+	*schema = inferred
+
+	return true, nil
+}
+
+// inferSchema creates schema from a decoded JSON value, numbers are expected as json.Number.
+func inferSchema(val interface{}) Schema {
+	s := Schema{}
+
+	switch v := val.(type) {
+	case nil:
+		s.AddType(Null)
+	case bool:
+		s.AddType(Boolean)
+	case json.Number:
+		if strings.ContainsAny(string(v), ".eE") {
+			s.AddType(Number)
+		} else {
+			s.AddType(Integer)
+		}
+	case float64:
+		s.AddType(Number)
+	case string:
+		s.AddType(String)
+	case []interface{}:
+		s.AddType(Array)
+
+		if len(v) > 0 {
+			items := inferSchema(v[0])
+			s.WithItems(Items{SchemaOrBool: &SchemaOrBool{TypeObject: &items}})
+		}
+	case map[string]interface{}:
+		s.AddType(Object)
+
+		for name, pv := range v {
+			ps := inferSchema(pv)
+			s.WithPropertiesItem(name, ps.ToSchemaOrBool())
+		}
+	}
+
+	return s
+}
+
+// InferFromJSON creates schema from sample JSON documents.
+//
+// Types observed across samples are combined (e.g. a property that is sometimes null becomes nullable,
+// integer and number are combined into number), object properties that are present in every sample
+// of the object are marked as required, array items are inferred from all elements.
+// Resulting schema is a starting point for documentation of existing payloads and may need refinement.
+func InferFromJSON(samples ...[]byte) (Schema, error) {
+	if len(samples) == 0 {
+		return Schema{}, errors.New("at least one sample is required")
+	}
+
+	o := &observation{}
+
+	for i, sample := range samples {
+		dec := json.NewDecoder(bytes.NewReader(sample))
+		dec.UseNumber()
+
+		var val interface{}
+
+		if err := dec.Decode(&val); err != nil {
+			return Schema{}, fmt.Errorf("decoding sample %d: %w", i, err)
+		}
+
+		o.observe(val)
+	}
+
+	return o.schema(), nil
+}
+
+// observation accumulates JSON values that are found at the same location of samples.
+type observation struct {
+	types      map[SimpleType]bool
+	objects    int
+	properties map[string]*observation
+	names      []string
+	present    map[string]int
+	items      *observation
+}
+
+func (o *observation) observe(val interface{}) {
+	if o.types == nil {
+		o.types = map[SimpleType]bool{}
+	}
+
+	switch v := val.(type) {
+	case []interface{}:
+		o.types[Array] = true
+
+		for _, item := range v {
+			if o.items == nil {
+				o.items = &observation{}
+			}
+
+			o.items.observe(item)
+		}
+	case map[string]interface{}:
+		o.types[Object] = true
+		o.objects++
+
+		if o.properties == nil {
+			o.properties = map[string]*observation{}
+			o.present = map[string]int{}
+		}
+
+		for name, pv := range v {
+			po, found := o.properties[name]
+			if !found {
+				po = &observation{}
+				o.properties[name] = po
+				o.names = append(o.names, name)
+			}
+
+			o.present[name]++
+			po.observe(pv)
+		}
+	default:
+		s := inferSchema(val)
+		o.types[s.simpleTypes()[0]] = true
+	}
+}
+
+func (o *observation) schema() Schema {
+	s := Schema{}
+
+	if o.types[Integer] && o.types[Number] {
+		delete(o.types, Integer)
+	}
+
+	for _, t := range []SimpleType{Array, Boolean, Integer, Number, Object, String, Null} {
+		if o.types[t] {
+			s.AddType(t)
+		}
+	}
+
+	if o.items != nil {
+		items := o.items.schema()
+		s.WithItems(Items{SchemaOrBool: &SchemaOrBool{TypeObject: &items}})
+	}
+
+	sort.Strings(o.names)
+
+	for _, name := range o.names {
+		ps := o.properties[name].schema()
+		s.WithPropertiesItem(name, ps.ToSchemaOrBool())
+
+		if o.present[name] == o.objects {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	return s
+}
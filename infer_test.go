@@ -0,0 +1,115 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+type wireMoney struct {
+	units int64
+	nanos int32
+}
+
+func (m wireMoney) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"amount":   float64(m.units) + float64(m.nanos)/1e9 + 0.5,
+		"currency": "USD",
+		"parts":    []int{1, 2},
+		"final":    true,
+		"note":     nil,
+	})
+}
+
+type failingMarshaler struct{}
+
+func (failingMarshaler) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("failed")
+}
+
+func TestInferJSONMarshalers(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Price    wireMoney  `json:"price"`
+		Discount *wireMoney `json:"discount"`
+	}
+
+	s, err := r.Reflect(My{}, jsonschema.InferJSONMarshalers)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestWireMoney":{
+		  "properties":{
+			"amount":{"type":"number"},"currency":{"type":"string"},
+			"final":{"type":"boolean"},"note":{"type":"null"},
+			"parts":{"items":{"type":"integer"},"type":"array"}
+		  },
+		  "type":"object"
+		}
+	  },
+	  "properties":{
+		"discount":{"$ref":"#/definitions/JsonschemaGoTestWireMoney"},
+		"price":{"$ref":"#/definitions/JsonschemaGoTestWireMoney"}
+	  },
+	  "type":"object"
+	}`, s)
+
+	s, err = r.Reflect(My{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{"JsonschemaGoTestWireMoney":{"type":"object"}},
+	  "properties":{
+		"discount":{"$ref":"#/definitions/JsonschemaGoTestWireMoney"},
+		"price":{"$ref":"#/definitions/JsonschemaGoTestWireMoney"}
+	  },
+	  "type":"object"
+	}`, s)
+
+	_, err = r.Reflect(failingMarshaler{}, jsonschema.InferJSONMarshalers)
+	assert.EqualError(t, err, "marshaling jsonschema_test.failingMarshaler sample: failed")
+}
+
+func TestInferFromJSON(t *testing.T) {
+	s, err := jsonschema.InferFromJSON(
+		[]byte(`{"id":1,"name":"foo","price":10,"tags":["a"],"owner":{"id":1,"email":"a@b.c"},"items":[{"sku":"x","qty":1}]}`),
+		[]byte(`{"id":2,"name":null,"price":10.5,"tags":[],"items":[{"sku":"y"},{"sku":"z","qty":2,"gift":true}]}`),
+		[]byte(`{"id":3,"name":"bar","price":1e2,"owner":null,"items":[]}`),
+	)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "required":["id","items","name","price"],
+	  "properties":{
+		"id":{"type":"integer"},
+		"items":{
+		  "items":{
+			"required":["sku"],
+			"properties":{"gift":{"type":"boolean"},"qty":{"type":"integer"},"sku":{"type":"string"}},
+			"type":"object"
+		  },
+		  "type":"array"
+		},
+		"name":{"type":["string","null"]},
+		"owner":{
+		  "required":["email","id"],
+		  "properties":{"email":{"type":"string"},"id":{"type":"integer"}},
+		  "type":["object","null"]
+		},
+		"price":{"type":"number"},
+		"tags":{"items":{"type":"string"},"type":"array"}
+	  },
+	  "type":"object"
+	}`, s)
+
+	_, err = jsonschema.InferFromJSON()
+	assert.EqualError(t, err, "at least one sample is required")
+
+	_, err = jsonschema.InferFromJSON([]byte(`{}`), []byte(`{`))
+	assert.EqualError(t, err, "decoding sample 1: unexpected EOF")
+}
@@ -0,0 +1,582 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// JTD is a JSON Type Definition (RFC 8927) schema, see ConvertToJTD.
+//
+// Non-nil empty Properties denotes properties form without properties, e.g. a discriminator mapping item
+// that only has a tag property.
+type JTD struct {
+	Definitions          map[string]JTD         `json:"definitions,omitempty"`
+	Metadata             map[string]interface{} `json:"metadata,omitempty"`
+	Nullable             bool                   `json:"nullable,omitempty"`
+	Ref                  string                 `json:"ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Elements             *JTD                   `json:"elements,omitempty"`
+	Properties           map[string]JTD         `json:"properties,omitempty"`
+	OptionalProperties   map[string]JTD         `json:"optionalProperties,omitempty"`
+	AdditionalProperties bool                   `json:"additionalProperties,omitempty"`
+	Values               *JTD                   `json:"values,omitempty"`
+	Discriminator        string                 `json:"discriminator,omitempty"`
+	Mapping              map[string]JTD         `json:"mapping,omitempty"`
+}
+
+// MarshalJSON encodes JTD schema.
+func (j JTD) MarshalJSON() ([]byte, error) {
+	type jtd JTD
+
+	b, err := json.Marshal(jtd(j))
+	if err != nil || j.Properties == nil || len(j.Properties) > 0 || len(j.OptionalProperties) > 0 {
+		return b, err
+	}
+
+	if string(b) == "{}" {
+		return []byte(`{"properties":{}}`), nil
+	}
+
+	return append([]byte(`{"properties":{},`), b[1:]...), nil
+}
+
+// ConvertToJTD converts schema to JSON Type Definition (RFC 8927) with warnings about lossy changes.
+//
+// Conversion is done on a copy of schema:
+//   - `allOf` items are flattened (see Schema.FlattenAllOf), definitions are exported as JTD definitions,
+//   - null type and `anyOf` with `{"type":"null"}` item are replaced with `nullable: true`,
+//   - `date-time` strings are exported as `timestamp`, string `enum` and `const` as `enum`,
+//   - integers are exported with the narrowest JTD integer type by Go kind (Schema.ReflectType) or bounds,
+//     integers that do not fit `int32` or `uint32` are exported as `float64`,
+//   - objects are exported as `properties` and `optionalProperties` (by `required`),
+//     objects without properties are exported as `values`,
+//   - `oneOf` or `anyOf` of objects with a common tag property (OpenAPI `discriminator` or `const` values)
+//     is exported as `discriminator` and `mapping`,
+//   - `description` is exported as `metadata.description`.
+//
+// Other keywords are not supported by JTD and are dropped with a warning, schemas that can not be
+// expressed are replaced with empty form that accepts any value.
+func ConvertToJTD(s Schema) (JTD, []Warning) {
+	c := jtdConverter{root: s.Clone()}
+
+	if err := c.root.FlattenAllOf(); err != nil {
+		c.warn("#", "failed to flatten allOf: %v", err)
+	}
+
+	j := c.convert("#", c.root, false)
+
+	if len(c.root.Definitions) > 0 {
+		j.Definitions = make(map[string]JTD, len(c.root.Definitions))
+
+		for name, def := range c.root.Definitions {
+			path := "#/definitions/" + escapePointer(name)
+
+			if def.TypeObject == nil {
+				c.warn(path, "boolean schema is not supported and was replaced with empty form")
+
+				j.Definitions[name] = JTD{}
+
+				continue
+			}
+
+			j.Definitions[name] = c.convert(path, *def.TypeObject, false)
+		}
+	}
+
+	sort.SliceStable(c.warnings, func(i, j int) bool {
+		return c.warnings[i].Path < c.warnings[j].Path
+	})
+
+	return j, c.warnings
+}
+
+type jtdConverter struct {
+	root     Schema
+	warnings []Warning
+}
+
+func (c *jtdConverter) warn(path, format string, args ...interface{}) {
+	c.warnings = append(c.warnings, Warning{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *jtdConverter) convertSchemaOrBool(path string, sb *SchemaOrBool) JTD {
+	if sb == nil || (sb.TypeBoolean != nil && *sb.TypeBoolean) {
+		return JTD{}
+	}
+
+	if sb.TypeObject == nil {
+		c.warn(path, "false schema is not supported and was replaced with empty form")
+
+		return JTD{}
+	}
+
+	return c.convert(path, *sb.TypeObject, false)
+}
+
+// convert exports schema, variant enforces properties form of discriminator mapping item.
+func (c *jtdConverter) convert(path string, s Schema, variant bool) JTD {
+	j := JTD{}
+
+	s, j.Nullable = unwrapJTDNull(s)
+
+	if s.Description != nil {
+		j.Metadata = map[string]interface{}{"description": *s.Description}
+	}
+
+	if variant && j.Nullable {
+		c.warn(path, "nullable discriminator mapping is not supported and was removed")
+
+		j.Nullable = false
+	}
+
+	if s.Ref != nil {
+		if name, ok := definitionName(*s.Ref); ok {
+			if _, found := c.root.Definitions[name]; found {
+				j.Ref = name
+
+				return j
+			}
+		}
+
+		c.warn(path, "reference %s is not supported and was replaced with empty form", *s.Ref)
+
+		return j
+	}
+
+	used := map[string]bool{}
+
+	if keyword, ok := c.convertDiscriminator(path, s, &j); ok {
+		used[keyword] = true
+		used["properties"] = true
+		used["required"] = true
+
+		c.dropUnsupported(path, s, used)
+
+		return j
+	}
+
+	var types []SimpleType
+
+	for _, t := range s.simpleTypes() {
+		if t != Null {
+			types = append(types, t)
+		}
+	}
+
+	if len(types) == 0 {
+		switch {
+		case variant || len(s.Properties) > 0:
+			types = []SimpleType{Object}
+		case s.Items != nil:
+			types = []SimpleType{Array}
+		case s.Const != nil || len(s.Enum) > 0:
+			types = []SimpleType{String}
+		}
+	}
+
+	switch {
+	case len(types) > 1:
+		c.warn(path, "multiple types are not supported and were replaced with empty form")
+	case variant:
+		c.convertObject(path, s, &j, true, used)
+	case len(types) == 0:
+	case types[0] == Object:
+		c.convertObject(path, s, &j, false, used)
+	case types[0] == Array:
+		c.convertArray(path, s, &j, used)
+	case types[0] == String:
+		c.convertString(s, &j, used)
+	case types[0] == Integer:
+		c.convertInteger(path, s, &j, used)
+	case types[0] == Number:
+		j.Type = "float64"
+
+		if s.ReflectType != nil && s.ReflectType.Kind() == reflect.Float32 {
+			j.Type = "float32"
+		}
+	case types[0] == Boolean:
+		j.Type = "boolean"
+	}
+
+	c.dropUnsupported(path, s, used)
+
+	return j
+}
+
+// unwrapJTDNull removes null type and `anyOf` null envelope (see ReflectContext.EnvelopNullability).
+func unwrapJTDNull(s Schema) (Schema, bool) {
+	nullable := false
+
+	if s.HasType(Null) {
+		nullable = true
+		t := *s.Type
+		s.Type = &t
+		s.RemoveType(Null)
+	}
+
+	if len(s.AnyOf) < 2 {
+		return s, nullable
+	}
+
+	var anyOf []SchemaOrBool
+
+	for _, item := range s.AnyOf {
+		if item.TypeObject != nil && item.TypeObject.HasType(Null) && len(item.TypeObject.simpleTypes()) == 1 &&
+			item.TypeObject.IsTrivial() {
+			nullable = true
+
+			continue
+		}
+
+		anyOf = append(anyOf, item)
+	}
+
+	if len(anyOf) != 1 || anyOf[0].TypeObject == nil {
+		s.AnyOf = anyOf
+
+		return s, nullable
+	}
+
+	inner := *anyOf[0].TypeObject
+	s.AnyOf = nil
+	mergeAnnotations(&inner, s)
+
+	return inner, nullable
+}
+
+// convertDiscriminator exports `oneOf` or `anyOf` of tagged objects, it returns used keyword.
+func (c *jtdConverter) convertDiscriminator(path string, s Schema, j *JTD) (string, bool) {
+	keyword, items := "oneOf", s.OneOf
+	if len(items) == 0 {
+		keyword, items = "anyOf", s.AnyOf
+	}
+
+	if len(items) == 0 {
+		return "", false
+	}
+
+	tag, refValues := discriminatorOf(s)
+	variants := make([]Schema, 0, len(items))
+	values := make([]string, 0, len(items))
+	seen := map[string]bool{}
+
+	for _, item := range items {
+		if item.TypeObject == nil {
+			return "", false
+		}
+
+		is := *item.TypeObject
+		value, hasValue := "", false
+
+		if is.Ref != nil {
+			value, hasValue = refValues[*is.Ref]
+
+			rs, found := c.root.ResolveRef(*is.Ref)
+			if !found {
+				return "", false
+			}
+
+			is = *rs
+		}
+
+		if tag == "" {
+			tag = inferTag(is)
+		}
+
+		if !hasValue {
+			value, hasValue = tagValue(is, tag)
+		}
+
+		if !hasValue || seen[value] || tag == "" || (!is.HasType(Object) && len(is.Properties) == 0) {
+			return "", false
+		}
+
+		seen[value] = true
+		variants = append(variants, is)
+		values = append(values, value)
+	}
+
+	j.Discriminator = tag
+	j.Mapping = make(map[string]JTD, len(variants))
+
+	for i, v := range variants {
+		j.Mapping[values[i]] = c.convert(path+"/"+keyword+"/"+strconv.Itoa(i), v.WithoutProperties(tag), true)
+	}
+
+	return keyword, true
+}
+
+// discriminatorOf returns property name and values by references of OpenAPI discriminator, if available.
+func discriminatorOf(s Schema) (string, map[string]string) {
+	refValues := map[string]string{}
+
+	switch d := s.ExtraProperties["discriminator"].(type) {
+	case Discriminator:
+		for value, ref := range d.Mapping {
+			refValues[ref] = value
+		}
+
+		return d.PropertyName, refValues
+	case map[string]interface{}:
+		propertyName, _ := d["propertyName"].(string)
+		mapping, _ := d["mapping"].(map[string]interface{})
+
+		for value, ref := range mapping {
+			if ref, ok := ref.(string); ok {
+				refValues[ref] = value
+			}
+		}
+
+		return propertyName, refValues
+	}
+
+	return "", refValues
+}
+
+// inferTag finds the first required property with a single string value.
+func inferTag(s Schema) string {
+	for _, name := range s.Required {
+		if _, ok := tagValue(s, name); ok {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func tagValue(s Schema, tag string) (string, bool) {
+	p, found := s.Properties[tag]
+	if !found || p.TypeObject == nil {
+		return "", false
+	}
+
+	if p.TypeObject.Const != nil {
+		v, ok := (*p.TypeObject.Const).(string)
+
+		return v, ok
+	}
+
+	if len(p.TypeObject.Enum) == 1 {
+		v, ok := p.TypeObject.Enum[0].(string)
+
+		return v, ok
+	}
+
+	return "", false
+}
+
+func (c *jtdConverter) convertObject(path string, s Schema, j *JTD, variant bool, used map[string]bool) {
+	used["properties"] = true
+	used["required"] = true
+	used["additionalProperties"] = true
+
+	ap := s.AdditionalProperties
+
+	if len(s.Properties) == 0 && !variant && (ap == nil || ap.TypeObject != nil || *ap.TypeBoolean) {
+		v := c.convertSchemaOrBool(path+"/additionalProperties", ap)
+		j.Values = &v
+
+		return
+	}
+
+	required := make(map[string]bool, len(s.Required))
+
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	for name, ps := range s.Properties {
+		ps := ps
+		p := c.convertSchemaOrBool(path+"/properties/"+escapePointer(name), &ps)
+
+		if required[name] {
+			if j.Properties == nil {
+				j.Properties = map[string]JTD{}
+			}
+
+			j.Properties[name] = p
+		} else {
+			if j.OptionalProperties == nil {
+				j.OptionalProperties = map[string]JTD{}
+			}
+
+			j.OptionalProperties[name] = p
+		}
+	}
+
+	if j.Properties == nil && j.OptionalProperties == nil {
+		j.Properties = map[string]JTD{}
+	}
+
+	switch {
+	case ap == nil:
+		j.AdditionalProperties = true
+	case ap.TypeBoolean != nil:
+		j.AdditionalProperties = *ap.TypeBoolean
+	default:
+		j.AdditionalProperties = true
+
+		if !ap.IsTrivial() {
+			c.warn(path, "additionalProperties schema is not supported, any additional properties are allowed")
+		}
+	}
+}
+
+func (c *jtdConverter) convertArray(path string, s Schema, j *JTD, used map[string]bool) {
+	used["items"] = true
+
+	e := JTD{}
+
+	switch {
+	case s.Items == nil:
+	case s.Items.SchemaOrBool != nil:
+		e = c.convertSchemaOrBool(path+"/items", s.Items.SchemaOrBool)
+	default:
+		c.warn(path, "tuple items are not supported and were replaced with empty form elements")
+	}
+
+	j.Elements = &e
+}
+
+func (c *jtdConverter) convertString(s Schema, j *JTD, used map[string]bool) {
+	if values, nullable, ok := stringEnum(s); ok {
+		used["const"] = true
+		used["enum"] = true
+
+		j.Enum = values
+		j.Nullable = j.Nullable || nullable
+
+		return
+	}
+
+	j.Type = "string"
+
+	if s.Format != nil && *s.Format == "date-time" {
+		used["format"] = true
+
+		j.Type = "timestamp"
+	}
+}
+
+// stringEnum returns unique values of string `const` or `enum`, null value makes it nullable.
+func stringEnum(s Schema) (values []string, nullable bool, ok bool) {
+	enum := s.Enum
+	if s.Const != nil {
+		enum = []interface{}{*s.Const}
+	}
+
+	if len(enum) == 0 {
+		return nil, false, false
+	}
+
+	seen := map[string]bool{}
+
+	for _, e := range enum {
+		if e == nil {
+			nullable = true
+
+			continue
+		}
+
+		v, ok := e.(string)
+		if !ok {
+			return nil, false, false
+		}
+
+		if !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+
+	return values, nullable, len(values) > 0
+}
+
+var jtdIntegers = []struct {
+	name     string
+	kind     reflect.Kind
+	min, max float64
+}{
+	{name: "int8", kind: reflect.Int8, min: math.MinInt8, max: math.MaxInt8},
+	{name: "uint8", kind: reflect.Uint8, min: 0, max: math.MaxUint8},
+	{name: "int16", kind: reflect.Int16, min: math.MinInt16, max: math.MaxInt16},
+	{name: "uint16", kind: reflect.Uint16, min: 0, max: math.MaxUint16},
+	{name: "int32", kind: reflect.Int32, min: math.MinInt32, max: math.MaxInt32},
+	{name: "uint32", kind: reflect.Uint32, min: 0, max: math.MaxUint32},
+}
+
+func (c *jtdConverter) convertInteger(path string, s Schema, j *JTD, used map[string]bool) {
+	for _, t := range jtdIntegers {
+		fits := s.ReflectType != nil && s.ReflectType.Kind() == t.kind
+
+		if !fits && s.Minimum != nil && s.Maximum != nil {
+			fits = *s.Minimum >= t.min && *s.Maximum <= t.max
+		}
+
+		if fits {
+			j.Type = t.name
+			used["minimum"] = s.Minimum == nil || *s.Minimum <= t.min
+			used["maximum"] = s.Maximum == nil || *s.Maximum >= t.max
+
+			return
+		}
+	}
+
+	c.warn(path, "integer does not fit int32 or uint32 and was exported as float64")
+
+	j.Type = "float64"
+}
+
+func (c *jtdConverter) dropUnsupported(path string, s Schema, used map[string]bool) {
+	for _, k := range []struct {
+		keyword string
+		present bool
+	}{
+		{keyword: "multipleOf", present: s.MultipleOf != nil},
+		{keyword: "maximum", present: s.Maximum != nil},
+		{keyword: "exclusiveMaximum", present: s.ExclusiveMaximum != nil},
+		{keyword: "minimum", present: s.Minimum != nil},
+		{keyword: "exclusiveMinimum", present: s.ExclusiveMinimum != nil},
+		{keyword: "maxLength", present: s.MaxLength != nil},
+		{keyword: "minLength", present: s.MinLength != 0},
+		{keyword: "pattern", present: s.Pattern != nil},
+		{keyword: "format", present: s.Format != nil},
+		{keyword: "items", present: s.Items != nil},
+		{keyword: "additionalItems", present: s.AdditionalItems != nil},
+		{keyword: "maxItems", present: s.MaxItems != nil},
+		{keyword: "minItems", present: s.MinItems != 0},
+		{keyword: "uniqueItems", present: s.UniqueItems != nil && *s.UniqueItems},
+		{keyword: "contains", present: s.Contains != nil},
+		{keyword: "minContains", present: s.MinContains != nil},
+		{keyword: "maxProperties", present: s.MaxProperties != nil},
+		{keyword: "minProperties", present: s.MinProperties != 0},
+		{keyword: "required", present: len(s.Required) > 0},
+		{keyword: "properties", present: len(s.Properties) > 0},
+		{keyword: "patternProperties", present: len(s.PatternProperties) > 0},
+		{keyword: "additionalProperties", present: s.AdditionalProperties != nil},
+		{keyword: "dependencies", present: len(s.Dependencies) > 0},
+		{keyword: "dependentRequired", present: len(s.DependentRequired) > 0},
+		{keyword: "propertyNames", present: s.PropertyNames != nil},
+		{keyword: "unevaluatedProperties", present: s.UnevaluatedProperties != nil},
+		{keyword: "const", present: s.Const != nil},
+		{keyword: "enum", present: len(s.Enum) > 0},
+		{keyword: "allOf", present: len(s.AllOf) > 0},
+		{keyword: "anyOf", present: len(s.AnyOf) > 0},
+		{keyword: "oneOf", present: len(s.OneOf) > 0},
+		{keyword: "not", present: s.Not != nil},
+		{keyword: "if", present: s.If != nil},
+		{keyword: "then", present: s.Then != nil},
+		{keyword: "else", present: s.Else != nil},
+		{keyword: "$dynamicRef", present: s.DynamicRef != nil},
+		{keyword: "$recursiveRef", present: s.RecursiveRef != nil},
+	} {
+		if k.present && !used[k.keyword] {
+			c.warn(path, "%s is not supported and was removed", k.keyword)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package jsonschema_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestConvertToJTD(t *testing.T) {
+	type Item struct {
+		Name string `json:"name" required:"true" description:"Item name." minLength:"1"`
+	}
+
+	type Order struct {
+		ID       uint32           `json:"id" required:"true"`
+		Priority int8             `json:"priority"`
+		Total    int              `json:"total"`
+		Price    float32          `json:"price"`
+		Status   string           `json:"status" enum:"new,done"`
+		Created  time.Time        `json:"created"`
+		Note     *string          `json:"note"`
+		Items    []Item           `json:"items"`
+		Tags     map[string]int16 `json:"tags"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(Order{})
+	require.NoError(t, err)
+
+	j, warnings := jsonschema.ConvertToJTD(s)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestItem":{
+		  "properties":{"name":{"metadata":{"description":"Item name."},"type":"string"}},
+		  "additionalProperties":true
+		}
+	  },
+	  "properties":{"id":{"type":"uint32"}},
+	  "optionalProperties":{
+		"created":{"type":"timestamp"},"items":{"nullable":true,"elements":{"ref":"JsonschemaGoTestItem"}},
+		"note":{"nullable":true,"type":"string"},"price":{"type":"float32"},"priority":{"type":"int8"},
+		"status":{"enum":["new","done"]},"tags":{"nullable":true,"values":{"type":"int16"}},
+		"total":{"type":"float64"}
+	  },
+	  "additionalProperties":true
+	}`, j)
+
+	assertjson.EqMarshal(t, `[
+	  {"path":"#/definitions/JsonschemaGoTestItem/properties/name","message":"minLength is not supported and was removed"},
+	  {"path":"#/properties/total","message":"integer does not fit int32 or uint32 and was exported as float64"}
+	]`, warnings)
+}
+
+func TestConvertToJTD_discriminator(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+	  "oneOf":[{"$ref":"#/definitions/Circle"},{"$ref":"#/definitions/Square"}],
+	  "discriminator":{"propertyName":"kind","mapping":{"circle":"#/definitions/Circle"}},
+	  "definitions":{
+		"Circle":{
+		  "type":"object","required":["kind","radius"],"additionalProperties":false,
+		  "properties":{"kind":{"type":"string"},"radius":{"type":"number","minimum":0}}
+		},
+		"Square":{"type":"object","required":["kind"],"properties":{"kind":{"const":"square"}}},
+		"Pair":{"type":["string","integer"]}
+	  }
+	}`)))
+
+	j, warnings := jsonschema.ConvertToJTD(s)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"Circle":{"properties":{"kind":{"type":"string"},"radius":{"type":"float64"}}},
+		"Pair":{},
+		"Square":{"properties":{"kind":{"enum":["square"]}},"additionalProperties":true}
+	  },
+	  "discriminator":"kind",
+	  "mapping":{
+		"circle":{"properties":{"radius":{"type":"float64"}}},
+		"square":{"properties":{},"additionalProperties":true}
+	  }
+	}`, j)
+
+	assert.Equal(t, []jsonschema.Warning{
+		{Path: "#/definitions/Circle/properties/radius", Message: "minimum is not supported and was removed"},
+		{Path: "#/definitions/Pair", Message: "multiple types are not supported and were replaced with empty form"},
+		{Path: "#/oneOf/0/properties/radius", Message: "minimum is not supported and was removed"},
+	}, warnings)
+}
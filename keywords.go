@@ -0,0 +1,137 @@
+package jsonschema
+
+import "strings"
+
+// StrictKeywords enables output that only contains keywords of reflected dialect, e.g. to pass AJV strict mode.
+//
+// Non-standard keywords of Schema.ExtraProperties (e.g. XEnumNames, ExtrasExposer values, `discriminator`)
+// are removed if envelope is empty, otherwise they are moved into an object stored under envelope keyword,
+// so that it can be registered as a single custom keyword in validator (e.g. `ajv.addKeyword("x-extensions")`).
+//
+// With OpenAPI30 or Swagger20 dialect, OpenAPI keywords and "x-*" vendor extensions are kept.
+func StrictKeywords(envelope string) func(rc *ReflectContext) {
+	return func(rc *ReflectContext) {
+		rc.StrictKeywords = true
+		rc.KeywordsEnvelope = envelope
+	}
+}
+
+// standardKeywords are keywords of newer JSON Schema dialects that are stored in Schema.ExtraProperties.
+var standardKeywords = map[string]bool{
+	"$defs":            true,
+	"$vocabulary":      true,
+	"$recursiveAnchor": true,
+	"prefixItems":      true,
+	"dependentSchemas": true,
+	"unevaluatedItems": true,
+	"maxContains":      true,
+	"contentSchema":    true,
+	"deprecated":       true,
+	"writeOnly":        true,
+}
+
+// openAPIKeywords are OpenAPI keywords that are stored in Schema.ExtraProperties.
+var openAPIKeywords = map[string]bool{
+	"nullable":      true,
+	"example":       true,
+	"discriminator": true,
+	"deprecated":    true,
+	"writeOnly":     true,
+	"xml":           true,
+	"externalDocs":  true,
+}
+
+func (rc *ReflectContext) strictKeywords(s *Schema) {
+	if !rc.StrictKeywords {
+		return
+	}
+
+	openAPI := rc.OpenAPI30 || rc.Swagger20
+
+	known := standardKeywords
+	if openAPI {
+		known = openAPIKeywords
+	}
+
+	stripKeywords(s, func(keyword string) bool {
+		for _, k := range knownKeysSchema {
+			if k == keyword {
+				return true
+			}
+		}
+
+		return known[keyword] || (openAPI && strings.HasPrefix(keyword, "x-"))
+	}, rc.KeywordsEnvelope)
+}
+
+// stripKeywords removes or moves to envelope unknown extra keywords of schema and its subschemas.
+func stripKeywords(s *Schema, known func(keyword string) bool, envelope string) {
+	s.eachSubSchema(func(_ string, sub *Schema) {
+		stripKeywords(sub, known, envelope)
+	})
+
+	for _, def := range s.Definitions {
+		if def.TypeObject != nil {
+			stripKeywords(def.TypeObject, known, envelope)
+		}
+	}
+
+	var moved map[string]interface{}
+
+	for k, v := range s.ExtraProperties {
+		if known(k) {
+			stripExtraSubSchemas(v, known, envelope)
+
+			continue
+		}
+
+		if k == envelope {
+			continue
+		}
+
+		delete(s.ExtraProperties, k)
+
+		if envelope == "" {
+			continue
+		}
+
+		if moved == nil {
+			moved, _ = s.ExtraProperties[envelope].(map[string]interface{})
+			if moved == nil {
+				moved = map[string]interface{}{}
+			}
+		}
+
+		moved[k] = v
+	}
+
+	if moved != nil {
+		s.WithExtraPropertiesItem(envelope, moved)
+	}
+
+	if len(s.ExtraProperties) == 0 {
+		s.ExtraProperties = nil
+	}
+}
+
+// stripExtraSubSchemas processes subschemas of dialect keywords, e.g. `$defs` or `prefixItems`.
+func stripExtraSubSchemas(v interface{}, known func(keyword string) bool, envelope string) {
+	visit := func(sb SchemaOrBool) {
+		if sb.TypeObject != nil {
+			stripKeywords(sb.TypeObject, known, envelope)
+		}
+	}
+
+	switch sv := v.(type) {
+	case SchemaOrBool:
+		visit(sv)
+	case []SchemaOrBool:
+		for _, sb := range sv {
+			visit(sb)
+		}
+	case map[string]SchemaOrBool:
+		for _, sb := range sv {
+			visit(sb)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestStrictKeywords(t *testing.T) {
+	type Order struct {
+		Extras withExtras       `json:"extras"`
+		Kind   withValNamedEnum `json:"kind"`
+		Tuple  [2]int           `json:"tuple"`
+		Old    string           `json:"old" deprecated:"true"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(Order{}, jsonschema.StrictKeywords(""), jsonschema.Draft202012)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"extras":{"$ref":"#/$defs/JsonschemaGoTestWithExtras"},
+		"kind":{"$ref":"#/$defs/JsonschemaGoTestWithValNamedEnum"},
+		"old":{"type":"string","deprecated":true},
+		"tuple":{"items":{"type":"integer"},"maxItems":2,"minItems":2,"type":["array","null"]}
+	  },
+	  "type":"object",
+	  "$defs":{
+		"JsonschemaGoTestWithExtras":{"properties":{"foo":{"type":"string"}},"type":"object"},
+		"JsonschemaGoTestWithValNamedEnum":{"enum":[""],"type":"string"}
+	  }
+	}`, s)
+
+	s, err = r.Reflect(Order{}, jsonschema.StrictKeywords("x-extensions"))
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestWithExtras":{
+		  "properties":{"foo":{"type":"string"}},"type":"object",
+		  "x-extensions":{"x-go-type":"withExtras","x-internal":true}
+		},
+		"JsonschemaGoTestWithValNamedEnum":{"enum":[""],"type":"string","x-extensions":{"x-enum-names":["n:"]}}
+	  },
+	  "properties":{
+		"extras":{"$ref":"#/definitions/JsonschemaGoTestWithExtras"},
+		"kind":{"$ref":"#/definitions/JsonschemaGoTestWithValNamedEnum"},
+		"old":{"type":"string","deprecated":true},
+		"tuple":{"items":{"type":"integer"},"maxItems":2,"minItems":2,"type":["array","null"]}
+	  },
+	  "type":"object"
+	}`, s)
+
+	s, err = r.Reflect(Order{}, jsonschema.StrictKeywords("x-extensions"), jsonschema.OpenAPI30)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestWithExtras":{
+		  "properties":{"foo":{"type":"string"}},"type":"object","x-go-type":"withExtras","x-internal":true
+		},
+		"JsonschemaGoTestWithValNamedEnum":{"enum":[""],"type":"string","x-enum-names":["n:"]}
+	  },
+	  "properties":{
+		"extras":{"$ref":"#/definitions/JsonschemaGoTestWithExtras"},
+		"kind":{"$ref":"#/definitions/JsonschemaGoTestWithValNamedEnum"},
+		"old":{"type":"string","deprecated":true},
+		"tuple":{"items":{"type":"integer"},"maxItems":2,"minItems":2,"type":"array","nullable":true}
+	  },
+	  "type":"object"
+	}`, s)
+}
@@ -0,0 +1,232 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// Issue describes an impossible or suspicious constraint found by Lint.
+type Issue struct {
+	// Path is a JSON Pointer of schema with issue, e.g. "#/properties/foo".
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// String implements fmt.Stringer.
+func (i Issue) String() string {
+	return i.Path + ": " + i.Message
+}
+
+// Lint checks schema and its subschemas (including definitions) for constraints that can not be satisfied
+// or are likely mistakes, for example minimum greater than maximum, required property that is not defined,
+// enum value that violates schema type or invalid pattern.
+//
+// It is useful to catch bad field tags in tests.
+func Lint(s Schema) []Issue {
+	l := linter{}
+
+	l.lint("#", &s)
+
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		return l.issues[i].Path < l.issues[j].Path
+	})
+
+	return l.issues
+}
+
+type linter struct {
+	issues []Issue
+}
+
+func (l *linter) add(path, format string, args ...interface{}) {
+	l.issues = append(l.issues, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) lint(path string, s *Schema) {
+	l.lintBounds(path, s)
+	l.lintKeywordTypes(path, s)
+	l.lintValues(path, s)
+	l.lintObject(path, s)
+
+	if s.Pattern != nil {
+		if _, err := regexp.Compile(*s.Pattern); err != nil {
+			l.add(path, "invalid pattern %q: %v", *s.Pattern, err)
+		}
+	}
+
+	for pattern := range s.PatternProperties {
+		if _, err := regexp.Compile(pattern); err != nil {
+			l.add(path, "invalid pattern property %q: %v", pattern, err)
+		}
+	}
+
+	s.eachSubSchema(func(ptr string, sub *Schema) {
+		l.lint(path+ptr, sub)
+	})
+
+	for name, def := range s.Definitions {
+		if def.TypeObject != nil {
+			l.lint(path+"/definitions/"+escapePointer(name), def.TypeObject)
+		}
+	}
+}
+
+func (l *linter) lintBounds(path string, s *Schema) {
+	lower, upper := s.Minimum, s.Maximum
+	if s.ExclusiveMinimum != nil && (lower == nil || *s.ExclusiveMinimum >= *lower) {
+		lower = s.ExclusiveMinimum
+	}
+
+	if s.ExclusiveMaximum != nil && (upper == nil || *s.ExclusiveMaximum <= *upper) {
+		upper = s.ExclusiveMaximum
+	}
+
+	if lower != nil && upper != nil {
+		exclusive := lower == s.ExclusiveMinimum || upper == s.ExclusiveMaximum
+
+		if *lower > *upper || (exclusive && *lower == *upper) {
+			l.add(path, "no number satisfies lower bound %v and upper bound %v", *lower, *upper)
+		}
+	}
+
+	if s.MultipleOf != nil && *s.MultipleOf <= 0 {
+		l.add(path, "multipleOf %v must be greater than 0", *s.MultipleOf)
+	}
+
+	if s.MaxLength != nil && s.MinLength > *s.MaxLength {
+		l.add(path, "minLength %d is greater than maxLength %d", s.MinLength, *s.MaxLength)
+	}
+
+	if s.MaxItems != nil && s.MinItems > *s.MaxItems {
+		l.add(path, "minItems %d is greater than maxItems %d", s.MinItems, *s.MaxItems)
+	}
+
+	if s.MaxProperties != nil && s.MinProperties > *s.MaxProperties {
+		l.add(path, "minProperties %d is greater than maxProperties %d", s.MinProperties, *s.MaxProperties)
+	}
+
+	if s.MaxProperties != nil && int64(len(s.Required)) > *s.MaxProperties {
+		l.add(path, "%d required properties exceed maxProperties %d", len(s.Required), *s.MaxProperties)
+	}
+}
+
+// lintKeywordTypes reports keywords that are not applicable to schema type.
+func (l *linter) lintKeywordTypes(path string, s *Schema) {
+	if s.Type == nil {
+		return
+	}
+
+	check := func(t SimpleType, keywords map[string]bool) {
+		if s.HasType(t) || (t == Number && s.HasType(Integer)) {
+			return
+		}
+
+		names := make([]string, 0, len(keywords))
+
+		for name, set := range keywords {
+			if set {
+				names = append(names, name)
+			}
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			l.add(path, "%s is not applicable to type %s", name, typeName(s.Type))
+		}
+	}
+
+	check(Number, map[string]bool{
+		"minimum":          s.Minimum != nil,
+		"maximum":          s.Maximum != nil,
+		"exclusiveMinimum": s.ExclusiveMinimum != nil,
+		"exclusiveMaximum": s.ExclusiveMaximum != nil,
+		"multipleOf":       s.MultipleOf != nil,
+	})
+
+	check(String, map[string]bool{
+		"minLength": s.MinLength != 0,
+		"maxLength": s.MaxLength != nil,
+		"pattern":   s.Pattern != nil,
+	})
+
+	check(Array, map[string]bool{
+		"minItems":    s.MinItems != 0,
+		"maxItems":    s.MaxItems != nil,
+		"uniqueItems": s.UniqueItems != nil,
+		"items":       s.Items != nil,
+		"minContains": s.MinContains != nil,
+	})
+
+	check(Object, map[string]bool{
+		"minProperties":     s.MinProperties != 0,
+		"maxProperties":     s.MaxProperties != nil,
+		"required":          len(s.Required) > 0,
+		"properties":        len(s.Properties) > 0,
+		"dependentRequired": len(s.DependentRequired) > 0,
+	})
+}
+
+// lintValues reports enum and const values that violate schema type.
+func (l *linter) lintValues(path string, s *Schema) {
+	if s.Type == nil {
+		return
+	}
+
+	for i, v := range s.Enum {
+		if errs := validateType("", s, normalizeJSON(v)); len(errs) > 0 {
+			l.add(path, "enum value %d (%v): %s", i, v, errs[0].Message)
+		}
+	}
+
+	if s.Const != nil {
+		if errs := validateType("", s, normalizeJSON(*s.Const)); len(errs) > 0 {
+			l.add(path, "const value %v: %s", *s.Const, errs[0].Message)
+		}
+	}
+}
+
+// lintObject reports required properties that are not defined while other properties are.
+func (l *linter) lintObject(path string, s *Schema) {
+	if len(s.Required) == 0 {
+		return
+	}
+
+	closed := s.AdditionalProperties != nil && s.AdditionalProperties.TypeBoolean != nil &&
+		!*s.AdditionalProperties.TypeBoolean
+
+	if len(s.Properties) == 0 && !closed {
+		return
+	}
+
+	seen := map[string]bool{}
+
+	for _, name := range s.Required {
+		if seen[name] {
+			l.add(path, "required property %s is listed more than once", name)
+
+			continue
+		}
+
+		seen[name] = true
+
+		if _, found := s.Properties[name]; found {
+			continue
+		}
+
+		matched := false
+
+		for pattern := range s.PatternProperties {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(name) {
+				matched = true
+
+				break
+			}
+		}
+
+		if !matched {
+			l.add(path, "required property %s is not defined in properties", name)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestLint(t *testing.T) {
+	type Inner struct {
+		Label string `json:"label" minLength:"5" maxLength:"3"`
+	}
+
+	type Outer struct {
+		_      struct{} `required:"missing"`
+		Age    int      `json:"age" minimum:"10" maximum:"5"`
+		Ratio  float64  `json:"ratio" exclusiveMinimum:"1" maximum:"1"`
+		Name   string   `json:"name" pattern:"[a-" minimum:"1"`
+		Tags   []string `json:"tags" minItems:"3" maxItems:"2"`
+		Inner  Inner    `json:"inner"`
+		Nested []Inner  `json:"nested"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(Outer{}, jsonschema.StripDefinitionNamePrefix("JsonschemaGoTest"))
+	require.NoError(t, err)
+
+	var lines []string
+	for _, i := range jsonschema.Lint(s) {
+		lines = append(lines, i.String())
+	}
+
+	assert.Equal(t, []string{
+		"#: required property missing is not defined in properties",
+		"#/definitions/Inner/properties/label: minLength 5 is greater than maxLength 3",
+		"#/properties/age: no number satisfies lower bound 10 and upper bound 5",
+		"#/properties/name: minimum is not applicable to type string",
+		"#/properties/name: invalid pattern \"[a-\": error parsing regexp: missing closing ]: `[a-`",
+		"#/properties/ratio: no number satisfies lower bound 1 and upper bound 1",
+		"#/properties/tags: minItems 3 is greater than maxItems 2",
+	}, lines)
+
+	type Valid struct {
+		ID    int                `json:"id" minimum:"1" required:"true"`
+		Tags  []string           `json:"tags" uniqueItems:"true" maxItems:"3"`
+		Sub   *Valid             `json:"sub"`
+		Items []*Valid           `json:"items"`
+		Any   interface{}        `json:"any"`
+		Map   map[string]float64 `json:"map" minProperties:"1"`
+	}
+
+	s, err = r.Reflect(Valid{})
+	require.NoError(t, err)
+	assert.Empty(t, jsonschema.Lint(s))
+
+	s = jsonschema.Schema{}
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "type":"string","const":1,"enum":["a",true],"additionalProperties":false,"required":["a","a"],"maxProperties":1,
+	  "patternProperties":{"^a$":{},"(":{}}
+	}`), &s))
+
+	lines = nil
+	for _, i := range jsonschema.Lint(s) {
+		lines = append(lines, i.String())
+	}
+
+	assert.Equal(t, []string{
+		"#: 2 required properties exceed maxProperties 1",
+		"#: maxProperties is not applicable to type string",
+		"#: required is not applicable to type string",
+		"#: enum value 1 (true): expected string, got boolean",
+		"#: const value 1: expected string, got integer",
+		"#: required property a is listed more than once",
+		"#: invalid pattern property \"(\": error parsing regexp: missing closing ): `(`",
+	}, lines)
+}
@@ -0,0 +1,305 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// RenderMarkdown renders human-readable documentation of schema and its definitions as Markdown.
+//
+// Each object schema is rendered as a table with property, type, required flag, constraints and description,
+// properties of inline nested objects are listed with dotted names. Root schema is rendered first (unless
+// it is only a reference), followed by a section per definition in alphabetical order.
+// References to definitions are rendered as links to their sections.
+func RenderMarkdown(s Schema) string {
+	var (
+		b     strings.Builder
+		defs  = make([]string, 0, len(s.Definitions))
+		title = "Schema"
+	)
+
+	for name := range s.Definitions {
+		defs = append(defs, name)
+	}
+
+	sort.Strings(defs)
+
+	if s.Title != nil {
+		title = *s.Title
+	}
+
+	if s.Ref == nil || len(s.Properties) > 0 {
+		renderMarkdownSection(&b, "#", title, s)
+	}
+
+	for _, name := range defs {
+		def := s.Definitions[name]
+		if def.TypeObject == nil {
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+
+		renderMarkdownSection(&b, "##", name, *def.TypeObject)
+	}
+
+	return b.String()
+}
+
+func renderMarkdownSection(b *strings.Builder, heading, title string, s Schema) {
+	b.WriteString(heading + " " + title + "\n\n")
+
+	if s.Description != nil {
+		b.WriteString(*s.Description + "\n\n")
+	}
+
+	if len(s.Properties) == 0 {
+		b.WriteString("Type: " + markdownType(s.ToSchemaOrBool()) + "\n")
+
+		if c := markdownConstraints(s); c != "" {
+			b.WriteString("\nConstraints: " + c + "\n")
+		}
+
+		return
+	}
+
+	b.WriteString("| Property | Type | Required | Constraints | Description |\n")
+	b.WriteString("|----------|------|----------|-------------|-------------|\n")
+
+	renderMarkdownProperties(b, "", s)
+}
+
+func renderMarkdownProperties(b *strings.Builder, prefix string, s Schema) {
+	required := make(map[string]bool, len(s.Required))
+
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(s.Properties))
+
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop := s.Properties[name]
+
+		var ps Schema
+		if prop.TypeObject != nil {
+			ps = *prop.TypeObject
+		}
+
+		req := ""
+		if required[name] {
+			req = "yes"
+		}
+
+		description := ""
+		if ps.Description != nil {
+			description = *ps.Description
+		} else if ps.Title != nil {
+			description = *ps.Title
+		}
+
+		fmt.Fprintf(b, "| `%s` | %s | %s | %s | %s |\n", markdownCell(prefix+name), markdownCell(markdownType(prop)),
+			req, markdownCell(markdownConstraints(ps)), markdownCell(description))
+
+		if ps.Ref == nil && len(ps.Properties) > 0 {
+			renderMarkdownProperties(b, prefix+name+".", ps)
+		}
+	}
+}
+
+// markdownType describes type of schema, references to definitions are rendered as links.
+func markdownType(sb SchemaOrBool) string {
+	if sb.TypeObject == nil {
+		if sb.TypeBoolean != nil && !*sb.TypeBoolean {
+			return "never"
+		}
+
+		return "any"
+	}
+
+	s := sb.TypeObject
+
+	if s.Ref != nil {
+		return markdownRef(*s.Ref)
+	}
+
+	for _, variants := range [][]SchemaOrBool{s.OneOf, s.AnyOf} {
+		if len(variants) == 0 {
+			continue
+		}
+
+		types := make([]string, 0, len(variants))
+
+		for _, v := range variants {
+			types = append(types, markdownType(v))
+		}
+
+		return "one of " + strings.Join(types, ", ")
+	}
+
+	simpleTypes := s.simpleTypes()
+
+	if len(simpleTypes) == 0 {
+		switch {
+		case len(s.Properties) > 0:
+			simpleTypes = []SimpleType{Object}
+		case s.Items != nil:
+			simpleTypes = []SimpleType{Array}
+		case len(s.AllOf) > 0:
+			types := make([]string, 0, len(s.AllOf))
+
+			for _, v := range s.AllOf {
+				types = append(types, markdownType(v))
+			}
+
+			return "all of " + strings.Join(types, ", ")
+		default:
+			return "any"
+		}
+	}
+
+	types := make([]string, 0, len(simpleTypes))
+
+	for _, t := range simpleTypes {
+		switch {
+		case t == Array && s.Items != nil && s.Items.SchemaOrBool != nil:
+			types = append(types, "array of "+markdownType(*s.Items.SchemaOrBool))
+		case t == Object && len(s.Properties) == 0 && s.AdditionalProperties != nil &&
+			s.AdditionalProperties.TypeObject != nil:
+			types = append(types, "map of "+markdownType(*s.AdditionalProperties))
+		default:
+			types = append(types, "`"+string(t)+"`")
+		}
+	}
+
+	return strings.Join(types, ", ")
+}
+
+func markdownRef(ref string) string {
+	pos := strings.LastIndex(ref, "/")
+	if !strings.HasPrefix(ref, "#/") || pos == -1 {
+		return "`" + ref + "`"
+	}
+
+	name := strings.ReplaceAll(strings.ReplaceAll(ref[pos+1:], "~1", "/"), "~0", "~")
+
+	return "[" + name + "](#" + markdownAnchor(name) + ")"
+}
+
+// markdownAnchor returns GitHub-style anchor of heading.
+func markdownAnchor(heading string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// markdownConstraints lists validation keywords of schema.
+func markdownConstraints(s Schema) string {
+	var c []string
+
+	addFloat := func(name string, v *float64) {
+		if v != nil {
+			c = append(c, name+": "+strconv.FormatFloat(*v, 'f', -1, 64))
+		}
+	}
+
+	addInt := func(name string, v *int64) {
+		if v != nil {
+			c = append(c, name+": "+strconv.FormatInt(*v, 10))
+		}
+	}
+
+	if s.Format != nil {
+		c = append(c, "format: "+*s.Format)
+	}
+
+	addFloat("minimum", s.Minimum)
+	addFloat("exclusiveMinimum", s.ExclusiveMinimum)
+	addFloat("maximum", s.Maximum)
+	addFloat("exclusiveMaximum", s.ExclusiveMaximum)
+	addFloat("multipleOf", s.MultipleOf)
+
+	if s.MinLength > 0 {
+		addInt("minLength", &s.MinLength)
+	}
+
+	addInt("maxLength", s.MaxLength)
+
+	if s.Pattern != nil {
+		c = append(c, "pattern: `"+*s.Pattern+"`")
+	}
+
+	if s.MinItems > 0 {
+		addInt("minItems", &s.MinItems)
+	}
+
+	addInt("maxItems", s.MaxItems)
+
+	if s.UniqueItems != nil && *s.UniqueItems {
+		c = append(c, "uniqueItems")
+	}
+
+	if s.MinProperties > 0 {
+		addInt("minProperties", &s.MinProperties)
+	}
+
+	addInt("maxProperties", s.MaxProperties)
+
+	if len(s.Enum) > 0 {
+		values := make([]string, 0, len(s.Enum))
+
+		for _, v := range s.Enum {
+			values = append(values, markdownValue(v))
+		}
+
+		c = append(c, "enum: "+strings.Join(values, ", "))
+	}
+
+	if s.Const != nil {
+		c = append(c, "const: "+markdownValue(*s.Const))
+	}
+
+	if s.Default != nil {
+		c = append(c, "default: "+markdownValue(*s.Default))
+	}
+
+	return strings.Join(c, ", ")
+}
+
+func markdownValue(v interface{}) string {
+	j, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("`%v`", v)
+	}
+
+	return "`" + string(j) + "`"
+}
+
+// markdownCell escapes table cell content.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
@@ -0,0 +1,53 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	type Address struct {
+		City string `json:"city" required:"true" description:"City name."`
+		Zip  string `json:"zip" pattern:"^[0-9]{5}$"`
+	}
+
+	type User struct {
+		ID      int               `json:"id" minimum:"1" required:"true" description:"Unique identifier."`
+		Role    string            `json:"role" enum:"admin,user" default:"user"`
+		Tags    []string          `json:"tags" maxItems:"3" uniqueItems:"true"`
+		Address *Address          `json:"address"`
+		Labels  map[string]string `json:"labels" description:"Free-form labels,\nmultiline | piped."`
+		Meta    struct {
+			Source string `json:"source"`
+		} `json:"meta"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(User{}, jsonschema.RootRef)
+	require.NoError(t, err)
+
+	assert.Equal(t, "## JsonschemaGoTestAddress\n\n"+
+		"| Property | Type | Required | Constraints | Description |\n"+
+		"|----------|------|----------|-------------|-------------|\n"+
+		"| `city` | `string` | yes |  | City name. |\n"+
+		"| `zip` | `string` |  | pattern: `^[0-9]{5}$` |  |\n"+
+		"\n"+
+		"## JsonschemaGoTestUser\n\n"+
+		"| Property | Type | Required | Constraints | Description |\n"+
+		"|----------|------|----------|-------------|-------------|\n"+
+		"| `address` | [JsonschemaGoTestAddress](#jsonschemagotestaddress) |  |  |  |\n"+
+		"| `id` | `integer` | yes | minimum: 1 | Unique identifier. |\n"+
+		"| `labels` | map of `string`, `null` |  |  | Free-form labels,<br>multiline \\| piped. |\n"+
+		"| `meta` | `object` |  |  |  |\n"+
+		"| `meta.source` | `string` |  |  |  |\n"+
+		"| `role` | `string` |  | enum: `\"admin\"`, `\"user\"`, default: `\"user\"` |  |\n"+
+		"| `tags` | array of `string`, `null` |  | maxItems: 3, uniqueItems |  |\n",
+		jsonschema.RenderMarkdown(s))
+
+	assert.Equal(t, "# Schema\n\nType: `string`\n\nConstraints: format: date-time\n",
+		jsonschema.RenderMarkdown(*(&jsonschema.Schema{}).WithType(jsonschema.String.Type()).WithFormat("date-time")))
+}
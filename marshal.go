@@ -0,0 +1,1026 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// MarshalJSON encodes known keywords of schema without reflection.
+//
+// It is called by generated Schema.MarshalJSON, extra properties are skipped as they are encoded by marshalUnion.
+func (m marshalSchema) MarshalJSON() ([]byte, error) {
+	s := Schema(m)
+
+	// Precise values of numeric keywords are encoded with extra properties.
+	for _, f := range s.numberFields() {
+		if s.hasPreciseNumber(f.name) {
+			*f.val = nil
+		}
+	}
+
+	s.ExtraProperties = nil
+
+	return s.appendJSON(make([]byte, 0, 64))
+}
+
+// numberField is a numeric keyword of schema.
+type numberField struct {
+	name string
+	val  **float64
+}
+
+// numberFields returns numeric keywords of schema in encoding order.
+func (s *Schema) numberFields() []numberField {
+	return []numberField{
+		{"multipleOf", &s.MultipleOf},
+		{"maximum", &s.Maximum},
+		{"exclusiveMaximum", &s.ExclusiveMaximum},
+		{"minimum", &s.Minimum},
+		{"exclusiveMinimum", &s.ExclusiveMinimum},
+	}
+}
+
+// hasPreciseNumber checks if numeric keyword has json.Number value in extra properties, see ReflectContext.BigNumbers.
+func (s *Schema) hasPreciseNumber(keyword string) bool {
+	_, ok := s.ExtraProperties[keyword].(json.Number)
+
+	return ok
+}
+
+// appendJSON appends JSON encoding of schema to b.
+//
+// It produces the same result as encoding/json with struct tags of Schema, but without reflection.
+func (s *Schema) appendJSON(b []byte) ([]byte, error) {
+	var err error
+
+	b = append(b, '{')
+	start := len(b)
+
+	b = appendStringField(b, start, "$id", s.ID)
+	b = appendStringField(b, start, "$schema", s.Schema)
+	b = appendStringField(b, start, "$ref", s.Ref)
+	b = appendStringField(b, start, "$comment", s.Comment)
+	b = appendStringField(b, start, "$anchor", s.Anchor)
+	b = appendStringField(b, start, "$dynamicAnchor", s.DynamicAnchor)
+	b = appendStringField(b, start, "$dynamicRef", s.DynamicRef)
+	b = appendStringField(b, start, "$recursiveRef", s.RecursiveRef)
+	b = appendStringField(b, start, "title", s.Title)
+	b = appendStringField(b, start, "description", s.Description)
+
+	if s.Default != nil {
+		if b, err = appendValue(appendKey(b, start, "default"), *s.Default); err != nil {
+			return nil, err
+		}
+	}
+
+	b = appendBoolField(b, start, "readOnly", s.ReadOnly)
+
+	if len(s.Examples) > 0 {
+		if b, err = appendValues(appendKey(b, start, "examples"), s.Examples); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range s.numberFields() {
+		if *f.val == nil || s.hasPreciseNumber(f.name) {
+			continue
+		}
+
+		if b, err = appendFloat(appendKey(b, start, f.name), **f.val); err != nil {
+			return nil, err
+		}
+	}
+
+	b = appendIntField(b, start, "maxLength", s.MaxLength)
+
+	if s.MinLength != 0 {
+		b = strconv.AppendInt(appendKey(b, start, "minLength"), s.MinLength, 10)
+	}
+
+	b = appendStringField(b, start, "pattern", s.Pattern)
+
+	if b, err = appendSchemaOrBoolField(b, start, "additionalItems", s.AdditionalItems); err != nil {
+		return nil, err
+	}
+
+	if s.Items != nil {
+		if b, err = s.Items.appendJSON(appendKey(b, start, "items")); err != nil {
+			return nil, err
+		}
+	}
+
+	b = appendIntField(b, start, "maxItems", s.MaxItems)
+
+	if s.MinItems != 0 {
+		b = strconv.AppendInt(appendKey(b, start, "minItems"), s.MinItems, 10)
+	}
+
+	b = appendBoolField(b, start, "uniqueItems", s.UniqueItems)
+
+	if b, err = appendSchemaOrBoolField(b, start, "contains", s.Contains); err != nil {
+		return nil, err
+	}
+
+	b = appendIntField(b, start, "minContains", s.MinContains)
+
+	b = appendIntField(b, start, "maxProperties", s.MaxProperties)
+
+	if s.MinProperties != 0 {
+		b = strconv.AppendInt(appendKey(b, start, "minProperties"), s.MinProperties, 10)
+	}
+
+	if len(s.Required) > 0 {
+		b = append(appendKey(b, start, "required"), '[')
+
+		for i, r := range s.Required {
+			if i > 0 {
+				b = append(b, ',')
+			}
+
+			b = appendString(b, r)
+		}
+
+		b = append(b, ']')
+	}
+
+	if b, err = appendSchemaOrBoolField(b, start, "additionalProperties", s.AdditionalProperties); err != nil {
+		return nil, err
+	}
+
+	if b, err = appendSchemaMapField(b, start, "definitions", s.Definitions); err != nil {
+		return nil, err
+	}
+
+	if b, err = appendSchemaMapField(b, start, "properties", s.Properties); err != nil {
+		return nil, err
+	}
+
+	if b, err = appendSchemaMapField(b, start, "patternProperties", s.PatternProperties); err != nil {
+		return nil, err
+	}
+
+	if len(s.Dependencies) > 0 {
+		j, err := json.Marshal(s.Dependencies)
+		if err != nil {
+			return nil, err
+		}
+
+		b = append(appendKey(b, start, "dependencies"), j...)
+	}
+
+	if len(s.DependentRequired) > 0 {
+		j, err := json.Marshal(s.DependentRequired)
+		if err != nil {
+			return nil, err
+		}
+
+		b = append(appendKey(b, start, "dependentRequired"), j...)
+	}
+
+	if b, err = appendSchemaOrBoolField(b, start, "propertyNames", s.PropertyNames); err != nil {
+		return nil, err
+	}
+
+	if s.Const != nil {
+		if b, err = appendValue(appendKey(b, start, "const"), *s.Const); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		if b, err = appendValues(appendKey(b, start, "enum"), s.Enum); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.Type != nil {
+		if b, err = s.Type.appendJSON(appendKey(b, start, "type")); err != nil {
+			return nil, err
+		}
+	}
+
+	b = appendStringField(b, start, "format", s.Format)
+	b = appendStringField(b, start, "contentMediaType", s.ContentMediaType)
+	b = appendStringField(b, start, "contentEncoding", s.ContentEncoding)
+
+	for _, f := range []struct {
+		name string
+		val  *SchemaOrBool
+	}{
+		{"if", s.If},
+		{"then", s.Then},
+		{"else", s.Else},
+	} {
+		if b, err = appendSchemaOrBoolField(b, start, f.name, f.val); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range []struct {
+		name string
+		val  []SchemaOrBool
+	}{
+		{"allOf", s.AllOf},
+		{"anyOf", s.AnyOf},
+		{"oneOf", s.OneOf},
+	} {
+		if len(f.val) == 0 {
+			continue
+		}
+
+		if b, err = appendSchemaOrBools(appendKey(b, start, f.name), f.val); err != nil {
+			return nil, err
+		}
+	}
+
+	if b, err = appendSchemaOrBoolField(b, start, "not", s.Not); err != nil {
+		return nil, err
+	}
+
+	if b, err = appendSchemaOrBoolField(b, start, "unevaluatedProperties", s.UnevaluatedProperties); err != nil {
+		return nil, err
+	}
+
+	if len(s.ExtraProperties) > 0 {
+		keys := make([]string, 0, len(s.ExtraProperties))
+
+		for k := range s.ExtraProperties {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if b, err = appendValue(appendKey(b, start, k), s.ExtraProperties[k]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return append(b, '}'), nil
+}
+
+// appendJSON appends JSON encoding of schema or boolean to b.
+func (s *SchemaOrBool) appendJSON(b []byte) ([]byte, error) {
+	switch {
+	case s.TypeObject != nil:
+		return s.TypeObject.appendJSON(b)
+	case s.TypeBoolean != nil:
+		return strconv.AppendBool(b, *s.TypeBoolean), nil
+	}
+
+	return nil, errors.New("missing typed value")
+}
+
+// appendJSON appends JSON encoding of items to b, ambiguous values are encoded with marshalUnion.
+func (i *Items) appendJSON(b []byte) ([]byte, error) {
+	switch {
+	case i.SchemaOrBool != nil && i.SchemaArray == nil:
+		return i.SchemaOrBool.appendJSON(b)
+	case i.SchemaOrBool == nil && i.SchemaArray != nil:
+		return appendSchemaOrBools(b, i.SchemaArray)
+	}
+
+	j, err := marshalUnion(i.SchemaOrBool, i.SchemaArray)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, j...), nil
+}
+
+// appendJSON appends JSON encoding of type to b, ambiguous values are encoded with marshalUnion.
+func (t *Type) appendJSON(b []byte) ([]byte, error) {
+	switch {
+	case t.SimpleTypes != nil && t.SliceOfSimpleTypeValues == nil:
+		return appendSimpleType(b, *t.SimpleTypes)
+	case t.SimpleTypes == nil && t.SliceOfSimpleTypeValues != nil:
+		var err error
+
+		b = append(b, '[')
+
+		for i, st := range t.SliceOfSimpleTypeValues {
+			if i > 0 {
+				b = append(b, ',')
+			}
+
+			if b, err = appendSimpleType(b, st); err != nil {
+				return nil, err
+			}
+		}
+
+		return append(b, ']'), nil
+	}
+
+	j, err := marshalUnion(t.SimpleTypes, t.SliceOfSimpleTypeValues)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, j...), nil
+}
+
+func appendSimpleType(b []byte, t SimpleType) ([]byte, error) {
+	switch t {
+	case Array, Boolean, Integer, Null, Number, Object, String:
+		return appendString(b, string(t)), nil
+	}
+
+	return nil, fmt.Errorf("unexpected SimpleType value: %v", t)
+}
+
+// appendKey appends object key, it is prefixed with comma if object has other keys after start position.
+func appendKey(b []byte, start int, key string) []byte {
+	if len(b) > start {
+		b = append(b, ',')
+	}
+
+	return append(appendString(b, key), ':')
+}
+
+func appendStringField(b []byte, start int, key string, val *string) []byte {
+	if val == nil {
+		return b
+	}
+
+	return appendString(appendKey(b, start, key), *val)
+}
+
+func appendBoolField(b []byte, start int, key string, val *bool) []byte {
+	if val == nil {
+		return b
+	}
+
+	return strconv.AppendBool(appendKey(b, start, key), *val)
+}
+
+func appendIntField(b []byte, start int, key string, val *int64) []byte {
+	if val == nil {
+		return b
+	}
+
+	return strconv.AppendInt(appendKey(b, start, key), *val, 10)
+}
+
+func appendSchemaOrBoolField(b []byte, start int, key string, val *SchemaOrBool) ([]byte, error) {
+	if val == nil {
+		return b, nil
+	}
+
+	return val.appendJSON(appendKey(b, start, key))
+}
+
+func appendSchemaMapField(b []byte, start int, key string, m map[string]SchemaOrBool) ([]byte, error) {
+	if len(m) == 0 {
+		return b, nil
+	}
+
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var err error
+
+	b = append(appendKey(b, start, key), '{')
+	mapStart := len(b)
+
+	for _, k := range keys {
+		v := m[k]
+
+		if b, err = v.appendJSON(appendKey(b, mapStart, k)); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(b, '}'), nil
+}
+
+func appendSchemaOrBools(b []byte, items []SchemaOrBool) ([]byte, error) {
+	var err error
+
+	b = append(b, '[')
+
+	for i := range items {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		if b, err = items[i].appendJSON(b); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(b, ']'), nil
+}
+
+// appendValue appends JSON encoding of arbitrary value, common types are encoded without reflection.
+func appendValue(b []byte, v interface{}) ([]byte, error) {
+	switch val := v.(type) {
+	case nil:
+		return append(b, "null"...), nil
+	case string:
+		return appendString(b, val), nil
+	case bool:
+		return strconv.AppendBool(b, val), nil
+	case float64:
+		return appendFloat(b, val)
+	case int:
+		return strconv.AppendInt(b, int64(val), 10), nil
+	case int64:
+		return strconv.AppendInt(b, val, 10), nil
+	case []interface{}:
+		if val == nil {
+			return append(b, "null"...), nil
+		}
+
+		return appendValues(b, val)
+	case SchemaOrBool:
+		return val.appendJSON(b)
+	case Schema:
+		return val.appendJSON(b)
+	}
+
+	j, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, j...), nil
+}
+
+func appendValues(b []byte, values []interface{}) ([]byte, error) {
+	var err error
+
+	b = append(b, '[')
+
+	for i, v := range values {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		if b, err = appendValue(b, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(b, ']'), nil
+}
+
+// appendFloat appends number in the same format as encoding/json.
+func appendFloat(b []byte, f float64) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, fmt.Errorf("json: unsupported value: %s", strconv.FormatFloat(f, 'g', -1, 64))
+	}
+
+	abs := math.Abs(f)
+	format := byte('f')
+
+	if abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+
+	b = strconv.AppendFloat(b, f, format, -1, 64)
+
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+
+	return b, nil
+}
+
+// appendString appends quoted string, strings that need escaping are encoded with encoding/json.
+func appendString(b []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 0x20 || c >= utf8.RuneSelf || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' {
+			j, _ := json.Marshal(s) //nolint:errchkjson // String encoding does not fail.
+
+			return append(b, j...)
+		}
+	}
+
+	b = append(b, '"')
+	b = append(b, s...)
+
+	return append(b, '"')
+}
+
+// unmarshalKeyword decodes value of a known keyword into schema field, it returns false for unknown keyword.
+//
+// Values of known keywords are decoded in a single pass, subschemas are decoded without
+// additional validation of JSON, because it is already validated by parent.
+func (s *Schema) unmarshalKeyword(key string, val []byte) (bool, error) {
+	var err error
+
+	switch key {
+	case "$id":
+		err = unmarshalString(val, &s.ID)
+	case "$schema":
+		err = unmarshalString(val, &s.Schema)
+	case "$ref":
+		err = unmarshalString(val, &s.Ref)
+	case "$comment":
+		err = unmarshalString(val, &s.Comment)
+	case "$anchor":
+		err = unmarshalString(val, &s.Anchor)
+	case "$dynamicAnchor":
+		err = unmarshalString(val, &s.DynamicAnchor)
+	case "$dynamicRef":
+		err = unmarshalString(val, &s.DynamicRef)
+	case "$recursiveRef":
+		err = unmarshalString(val, &s.RecursiveRef)
+	case "title":
+		err = unmarshalString(val, &s.Title)
+	case "description":
+		err = unmarshalString(val, &s.Description)
+	case "default":
+		err = unmarshalInterface(val, &s.Default)
+	case "readOnly":
+		err = json.Unmarshal(val, &s.ReadOnly)
+	case "examples":
+		err = json.Unmarshal(val, &s.Examples)
+	case "multipleOf":
+		err = unmarshalFloat(val, &s.MultipleOf)
+	case "maximum":
+		err = unmarshalFloat(val, &s.Maximum)
+	case "exclusiveMaximum":
+		err = unmarshalFloat(val, &s.ExclusiveMaximum)
+	case "minimum":
+		err = unmarshalFloat(val, &s.Minimum)
+	case "exclusiveMinimum":
+		err = unmarshalFloat(val, &s.ExclusiveMinimum)
+	case "maxLength":
+		err = unmarshalIntPtr(val, &s.MaxLength)
+	case "minLength":
+		err = unmarshalInt(val, &s.MinLength)
+	case "pattern":
+		err = unmarshalString(val, &s.Pattern)
+	case "additionalItems":
+		err = unmarshalSchemaOrBool(val, &s.AdditionalItems)
+	case "items":
+		err = unmarshalItems(val, &s.Items)
+	case "maxItems":
+		err = unmarshalIntPtr(val, &s.MaxItems)
+	case "minItems":
+		err = unmarshalInt(val, &s.MinItems)
+	case "uniqueItems":
+		err = json.Unmarshal(val, &s.UniqueItems)
+	case "contains":
+		err = unmarshalSchemaOrBool(val, &s.Contains)
+	case "minContains":
+		err = unmarshalIntPtr(val, &s.MinContains)
+	case "maxProperties":
+		err = unmarshalIntPtr(val, &s.MaxProperties)
+	case "minProperties":
+		err = unmarshalInt(val, &s.MinProperties)
+	case "required":
+		err = json.Unmarshal(val, &s.Required)
+	case "additionalProperties":
+		err = unmarshalSchemaOrBool(val, &s.AdditionalProperties)
+	case "definitions":
+		err = unmarshalSchemaMap(val, &s.Definitions)
+	case "properties":
+		err = unmarshalSchemaMap(val, &s.Properties)
+	case "patternProperties":
+		err = unmarshalSchemaMap(val, &s.PatternProperties)
+	case "dependencies":
+		err = json.Unmarshal(val, &s.Dependencies)
+	case "dependentRequired":
+		err = json.Unmarshal(val, &s.DependentRequired)
+	case "propertyNames":
+		err = unmarshalSchemaOrBool(val, &s.PropertyNames)
+	case "const":
+		err = unmarshalInterface(val, &s.Const)
+	case "enum":
+		err = json.Unmarshal(val, &s.Enum)
+	case "type":
+		err = json.Unmarshal(val, &s.Type)
+	case "format":
+		err = unmarshalString(val, &s.Format)
+	case "contentMediaType":
+		err = unmarshalString(val, &s.ContentMediaType)
+	case "contentEncoding":
+		err = unmarshalString(val, &s.ContentEncoding)
+	case "if":
+		err = unmarshalSchemaOrBool(val, &s.If)
+	case "then":
+		err = unmarshalSchemaOrBool(val, &s.Then)
+	case "else":
+		err = unmarshalSchemaOrBool(val, &s.Else)
+	case "allOf":
+		err = unmarshalSchemaOrBools(val, &s.AllOf)
+	case "anyOf":
+		err = unmarshalSchemaOrBools(val, &s.AnyOf)
+	case "oneOf":
+		err = unmarshalSchemaOrBools(val, &s.OneOf)
+	case "not":
+		err = unmarshalSchemaOrBool(val, &s.Not)
+	case "unevaluatedProperties":
+		err = unmarshalSchemaOrBool(val, &s.UnevaluatedProperties)
+	default:
+		return false, nil
+	}
+
+	if err != nil {
+		return true, fmt.Errorf("%s: %w", key, err)
+	}
+
+	return true, nil
+}
+
+func isNull(val []byte) bool {
+	return string(val) == "null"
+}
+
+// UnmarshalJSON decodes known keywords and extra properties of schema in a single pass.
+//
+// It is called by generated Schema.UnmarshalJSON with JSON that is already validated by encoding/json.
+func (m *marshalSchema) UnmarshalJSON(data []byte) error {
+	return (*Schema)(m).decodeJSON(data)
+}
+
+// decodeJSON decodes valid JSON into schema.
+func (s *Schema) decodeJSON(data []byte) error {
+	if isNull(data) {
+		return nil
+	}
+
+	ms := *s
+
+	err := decodeObject(data, reflect.TypeOf(ms), func(key string, val []byte) error {
+		known, err := ms.unmarshalKeyword(key, val)
+		if err != nil || known {
+			return err
+		}
+
+		if ms.ExtraProperties == nil {
+			ms.ExtraProperties = make(map[string]interface{}, 1)
+		}
+
+		var v interface{}
+
+		if err := json.Unmarshal(val, &v); err != nil {
+			return err
+		}
+
+		ms.ExtraProperties[key] = v
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	*s = ms
+
+	return nil
+}
+
+// decodeJSON decodes valid JSON into schema or boolean.
+func (s *SchemaOrBool) decodeJSON(data []byte) error {
+	switch firstByte(data) {
+	case '{':
+		s.TypeBoolean = nil
+
+		if s.TypeObject == nil {
+			s.TypeObject = new(Schema)
+		}
+
+		if err := s.TypeObject.decodeJSON(data); err != nil {
+			s.TypeObject = nil
+
+			return err
+		}
+
+		return nil
+	case 'n':
+		s.TypeObject = nil
+
+		return nil
+	}
+
+	s.TypeObject = nil
+
+	err := json.Unmarshal(data, &s.TypeBoolean)
+	if err != nil {
+		s.TypeBoolean = nil
+	}
+
+	return err
+}
+
+// decodeJSON decodes valid JSON of schema, boolean or array of them, it returns false for other values.
+func (i *Items) decodeJSON(data []byte) (bool, error) {
+	switch firstByte(data) {
+	case '{', 't', 'f':
+		i.SchemaArray = nil
+
+		return true, unmarshalSchemaOrBool(data, &i.SchemaOrBool)
+	case '[':
+		i.SchemaOrBool = nil
+
+		return true, unmarshalSchemaOrBools(data, &i.SchemaArray)
+	}
+
+	return false, nil
+}
+
+func unmarshalString(val []byte, p **string) error {
+	if isNull(val) {
+		*p = nil
+
+		return nil
+	}
+
+	// Fast path for strings without escape sequences.
+	if len(val) >= 2 && val[0] == '"' && val[len(val)-1] == '"' && bytes.IndexByte(val, '\\') == -1 {
+		v := string(val[1 : len(val)-1])
+		*p = &v
+
+		return nil
+	}
+
+	return json.Unmarshal(val, p)
+}
+
+// unmarshalInterface decodes arbitrary value, JSON null is decoded as a pointer to nil.
+func unmarshalInterface(val []byte, p **interface{}) error {
+	var v interface{}
+
+	if err := json.Unmarshal(val, &v); err != nil {
+		return err
+	}
+
+	*p = &v
+
+	return nil
+}
+
+func unmarshalFloat(val []byte, p **float64) error {
+	if len(val) > 0 && (val[0] == '-' || (val[0] >= '0' && val[0] <= '9')) {
+		if v, err := strconv.ParseFloat(string(val), 64); err == nil {
+			*p = &v
+
+			return nil
+		}
+	}
+
+	return json.Unmarshal(val, p)
+}
+
+func unmarshalInt(val []byte, p *int64) error {
+	if v, err := strconv.ParseInt(string(val), 10, 64); err == nil {
+		*p = v
+
+		return nil
+	}
+
+	return json.Unmarshal(val, p)
+}
+
+func unmarshalIntPtr(val []byte, p **int64) error {
+	if v, err := strconv.ParseInt(string(val), 10, 64); err == nil {
+		*p = &v
+
+		return nil
+	}
+
+	return json.Unmarshal(val, p)
+}
+
+func unmarshalSchemaOrBool(val []byte, p **SchemaOrBool) error {
+	if isNull(val) {
+		*p = nil
+
+		return nil
+	}
+
+	if *p == nil {
+		*p = new(SchemaOrBool)
+	}
+
+	return (*p).decodeJSON(val)
+}
+
+func unmarshalItems(val []byte, p **Items) error {
+	if isNull(val) {
+		*p = nil
+
+		return nil
+	}
+
+	if *p == nil {
+		*p = new(Items)
+	}
+
+	if ok, err := (*p).decodeJSON(val); ok {
+		return err
+	}
+
+	return (*p).UnmarshalJSON(val)
+}
+
+func unmarshalSchemaOrBools(val []byte, p *[]SchemaOrBool) error {
+	if isNull(val) {
+		*p = nil
+
+		return nil
+	}
+
+	if firstByte(val) != '[' {
+		return &json.UnmarshalTypeError{Value: jsonKind(val), Type: reflect.TypeOf(*p)}
+	}
+
+	res := make([]SchemaOrBool, 0, 4)
+
+	err := eachValue(val[1:], ']', func(item []byte) error {
+		res = append(res, SchemaOrBool{})
+
+		if err := res[len(res)-1].decodeJSON(item); err != nil {
+			return fmt.Errorf("%d: %w", len(res)-1, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	*p = res
+
+	return nil
+}
+
+func unmarshalSchemaMap(val []byte, p *map[string]SchemaOrBool) error {
+	if isNull(val) {
+		*p = nil
+
+		return nil
+	}
+
+	return decodeObject(val, reflect.TypeOf(*p), func(name string, item []byte) error {
+		var sb SchemaOrBool
+
+		if err := sb.decodeJSON(item); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		if *p == nil {
+			*p = make(map[string]SchemaOrBool)
+		}
+
+		(*p)[name] = sb
+
+		return nil
+	})
+}
+
+// decodeObject calls f for every member of valid JSON object, t is used to report type mismatch.
+func decodeObject(data []byte, t reflect.Type, f func(key string, val []byte) error) error {
+	data = bytes.TrimLeft(data, " \t\r\n")
+
+	if len(data) == 0 || data[0] != '{' {
+		return &json.UnmarshalTypeError{Value: jsonKind(data), Type: t}
+	}
+
+	var key []byte
+
+	return eachValue(data[1:], '}', func(val []byte) error {
+		if key == nil {
+			key = val
+
+			return nil
+		}
+
+		k := key
+		key = nil
+
+		if bytes.IndexByte(k, '\\') == -1 {
+			return f(string(k[1:len(k)-1]), val)
+		}
+
+		var name string
+
+		if err := json.Unmarshal(k, &name); err != nil {
+			return err
+		}
+
+		return f(name, val)
+	})
+}
+
+// eachValue calls f for every value of valid JSON array or object (keys and values) until closing byte.
+func eachValue(data []byte, closing byte, f func(val []byte) error) error {
+	for i := 0; i < len(data); {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			i++
+
+			continue
+		case closing:
+			return nil
+		}
+
+		end := skipValue(data, i)
+
+		if err := f(data[i:end]); err != nil {
+			return err
+		}
+
+		i = end
+	}
+
+	return nil
+}
+
+// skipValue returns position after valid JSON value that starts at i.
+func skipValue(data []byte, i int) int {
+	switch data[i] {
+	case '"':
+		return skipString(data, i)
+	case '{', '[':
+		depth := 0
+
+		for j := i; j < len(data); j++ {
+			switch data[j] {
+			case '"':
+				j = skipString(data, j) - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+
+				if depth == 0 {
+					return j + 1
+				}
+			}
+		}
+
+		return len(data)
+	}
+
+	for j := i; j < len(data); j++ {
+		switch data[j] {
+		case ' ', '\t', '\r', '\n', ',', ':', '}', ']':
+			return j
+		}
+	}
+
+	return len(data)
+}
+
+// skipString returns position after valid JSON string that starts at i.
+func skipString(data []byte, i int) int {
+	for j := i + 1; j < len(data); j++ {
+		switch data[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1
+		}
+	}
+
+	return len(data)
+}
+
+// jsonKind returns kind of JSON value for type mismatch error.
+func jsonKind(data []byte) string {
+	switch firstByte(data) {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "bool"
+	}
+
+	return "number"
+}
+
+// firstByte returns first non-whitespace byte of JSON value.
+func firstByte(data []byte) byte {
+	for _, c := range data {
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			return c
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,538 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Merge combines constraints of src into dst, so that resulting dst validates values that are valid for both.
+//
+// Keywords that can be intersected (e.g. type, enum, bounds, properties, required) are combined,
+// conflicting keywords that can not be intersected (e.g. different patterns or consts) result in error.
+// Annotations (e.g. title, description, default) of dst take precedence.
+func Merge(dst, src *Schema) error {
+	if src.Ref != nil {
+		return fmt.Errorf("can not merge reference %s, resolve it first", *src.Ref)
+	}
+
+	if dst.Ref != nil {
+		return fmt.Errorf("can not merge into reference %s, resolve it first", *dst.Ref)
+	}
+
+	s := src.Clone()
+
+	mergeAnnotations(dst, s)
+
+	if err := mergeTypes(dst, s); err != nil {
+		return err
+	}
+
+	if err := mergeValues(dst, s); err != nil {
+		return err
+	}
+
+	mergeBounds(dst, s)
+
+	if err := mergeMultipleOf(dst, s); err != nil {
+		return err
+	}
+
+	if err := mergeStrings(dst, s); err != nil {
+		return err
+	}
+
+	if err := mergeObjects(dst, s); err != nil {
+		return err
+	}
+
+	if err := mergeArrays(dst, s); err != nil {
+		return err
+	}
+
+	return mergeCompositions(dst, s)
+}
+
+// FlattenAllOf merges "allOf" items into their parent schemas recursively, including definitions.
+//
+// Local references in "allOf" items are resolved against the Schema, use PruneUnusedDefinitions
+// afterwards to remove definitions that are not referenced anymore.
+func (s *Schema) FlattenAllOf() error {
+	return s.flattenAllOf(s, map[*Schema]bool{})
+}
+
+func (s *Schema) flattenAllOf(root *Schema, seen map[*Schema]bool) error {
+	if seen[s] {
+		return nil
+	}
+
+	seen[s] = true
+
+	var err error
+
+	s.eachSubSchema(func(_ string, sub *Schema) {
+		if err == nil {
+			err = sub.flattenAllOf(root, seen)
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for name, def := range s.Definitions {
+		if def.TypeObject != nil {
+			if err := def.TypeObject.flattenAllOf(root, seen); err != nil {
+				return fmt.Errorf("definition %s: %w", name, err)
+			}
+		}
+	}
+
+	if len(s.AllOf) == 0 {
+		return nil
+	}
+
+	allOf := s.AllOf
+	s.AllOf = nil
+
+	for i, item := range allOf {
+		if item.TypeBoolean != nil {
+			if !*item.TypeBoolean {
+				return fmt.Errorf("allOf[%d]: false schema can not be merged", i)
+			}
+
+			continue
+		}
+
+		is := item.TypeObject
+
+		if is.Ref != nil {
+			rs, found := root.ResolveRef(*is.Ref)
+			if !found {
+				return fmt.Errorf("allOf[%d]: unresolved reference %s", i, *is.Ref)
+			}
+
+			if rs == root || rs == s {
+				return fmt.Errorf("allOf[%d]: recursive reference %s can not be merged", i, *is.Ref)
+			}
+
+			if err := rs.flattenAllOf(root, seen); err != nil {
+				return err
+			}
+
+			is = rs
+		}
+
+		if err := Merge(s, is); err != nil {
+			return fmt.Errorf("allOf[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func mergeAnnotations(dst *Schema, src Schema) {
+	if dst.ID == nil {
+		dst.ID = src.ID
+	}
+
+	if dst.Comment == nil {
+		dst.Comment = src.Comment
+	}
+
+	if dst.Anchor == nil {
+		dst.Anchor = src.Anchor
+	}
+
+	if dst.DynamicAnchor == nil {
+		dst.DynamicAnchor = src.DynamicAnchor
+	}
+
+	if dst.Title == nil {
+		dst.Title = src.Title
+	}
+
+	if dst.Description == nil {
+		dst.Description = src.Description
+	}
+
+	if dst.Default == nil {
+		dst.Default = src.Default
+	}
+
+	if dst.Examples == nil {
+		dst.Examples = src.Examples
+	}
+
+	if dst.ContentMediaType == nil {
+		dst.ContentMediaType = src.ContentMediaType
+	}
+
+	if dst.ContentEncoding == nil {
+		dst.ContentEncoding = src.ContentEncoding
+	}
+
+	for k, v := range src.ExtraProperties {
+		if _, found := dst.ExtraProperties[k]; !found {
+			dst.WithExtraPropertiesItem(k, v)
+		}
+	}
+
+	for name, def := range src.Definitions {
+		if _, found := dst.Definitions[name]; !found {
+			dst.WithDefinitionsItem(name, def)
+		}
+	}
+}
+
+func mergeTypes(dst *Schema, src Schema) error {
+	st := src.simpleTypes()
+	if len(st) == 0 {
+		return nil
+	}
+
+	dt := dst.simpleTypes()
+	if len(dt) == 0 {
+		dst.Type = src.Type
+
+		return nil
+	}
+
+	var res []SimpleType
+
+	for _, t := range dt {
+		switch {
+		case src.HasType(t):
+			res = append(res, t)
+		case t == Number && src.HasType(Integer):
+			res = append(res, Integer)
+		case t == Integer && src.HasType(Number):
+			res = append(res, Integer)
+		}
+	}
+
+	if len(res) == 0 {
+		return fmt.Errorf("conflicting types: %v and %v", dt, st)
+	}
+
+	dst.Type = nil
+
+	for _, t := range res {
+		dst.AddType(t)
+	}
+
+	return nil
+}
+
+func mergeValues(dst *Schema, src Schema) error {
+	if src.Const != nil {
+		if dst.Const != nil && !equalValue(reflect.ValueOf(*dst.Const), reflect.ValueOf(*src.Const)) {
+			return fmt.Errorf("conflicting const: %v and %v", *dst.Const, *src.Const)
+		}
+
+		dst.Const = src.Const
+	}
+
+	if src.Enum == nil {
+		return nil
+	}
+
+	if dst.Enum == nil {
+		dst.Enum = src.Enum
+
+		return nil
+	}
+
+	var enum []interface{}
+
+	for _, dv := range dst.Enum {
+		for _, sv := range src.Enum {
+			if equalValue(reflect.ValueOf(dv), reflect.ValueOf(sv)) {
+				enum = append(enum, dv)
+
+				break
+			}
+		}
+	}
+
+	if len(enum) == 0 {
+		return errors.New("conflicting enum: no common values")
+	}
+
+	dst.Enum = enum
+
+	return nil
+}
+
+func mergeBounds(dst *Schema, src Schema) {
+	dst.Minimum = maxFloat(dst.Minimum, src.Minimum)
+	dst.ExclusiveMinimum = maxFloat(dst.ExclusiveMinimum, src.ExclusiveMinimum)
+	dst.Maximum = minFloat(dst.Maximum, src.Maximum)
+	dst.ExclusiveMaximum = minFloat(dst.ExclusiveMaximum, src.ExclusiveMaximum)
+
+	dst.MaxLength = minInt(dst.MaxLength, src.MaxLength)
+	dst.MaxItems = minInt(dst.MaxItems, src.MaxItems)
+	dst.MaxProperties = minInt(dst.MaxProperties, src.MaxProperties)
+
+	if src.MinLength > dst.MinLength {
+		dst.MinLength = src.MinLength
+	}
+
+	if src.MinItems > dst.MinItems {
+		dst.MinItems = src.MinItems
+	}
+
+	if src.MinProperties > dst.MinProperties {
+		dst.MinProperties = src.MinProperties
+	}
+
+	if src.UniqueItems != nil && *src.UniqueItems {
+		dst.UniqueItems = src.UniqueItems
+	}
+
+	if dst.ReadOnly == nil || (src.ReadOnly != nil && *src.ReadOnly) {
+		dst.ReadOnly = src.ReadOnly
+	}
+}
+
+func mergeMultipleOf(dst *Schema, src Schema) error {
+	if src.MultipleOf == nil {
+		return nil
+	}
+
+	if dst.MultipleOf == nil {
+		dst.MultipleOf = src.MultipleOf
+
+		return nil
+	}
+
+	d, s := *dst.MultipleOf, *src.MultipleOf
+
+	switch {
+	case isMultiple(d, s):
+	case isMultiple(s, d):
+		dst.MultipleOf = src.MultipleOf
+	default:
+		return fmt.Errorf("conflicting multipleOf: %v and %v", d, s)
+	}
+
+	return nil
+}
+
+func isMultiple(v, of float64) bool {
+	if of == 0 {
+		return false
+	}
+
+	r := v / of
+
+	return r == math.Trunc(r)
+}
+
+func mergeStrings(dst *Schema, src Schema) error {
+	if src.Pattern != nil {
+		if dst.Pattern != nil && *dst.Pattern != *src.Pattern {
+			return fmt.Errorf("conflicting pattern: %s and %s", *dst.Pattern, *src.Pattern)
+		}
+
+		dst.Pattern = src.Pattern
+	}
+
+	if src.Format != nil {
+		if dst.Format != nil && *dst.Format != *src.Format {
+			return fmt.Errorf("conflicting format: %s and %s", *dst.Format, *src.Format)
+		}
+
+		dst.Format = src.Format
+	}
+
+	return nil
+}
+
+func mergeObjects(dst *Schema, src Schema) error {
+	addRequired(dst, src.Required...)
+
+	for name, ps := range src.Properties {
+		merged, err := mergeSchemaOrBoolItem(dst.Properties, name, ps)
+		if err != nil {
+			return fmt.Errorf("property %s: %w", name, err)
+		}
+
+		dst.WithPropertiesItem(name, merged)
+	}
+
+	for pattern, ps := range src.PatternProperties {
+		merged, err := mergeSchemaOrBoolItem(dst.PatternProperties, pattern, ps)
+		if err != nil {
+			return fmt.Errorf("pattern property %s: %w", pattern, err)
+		}
+
+		dst.WithPatternPropertiesItem(pattern, merged)
+	}
+
+	for name, d := range src.Dependencies {
+		if _, found := dst.Dependencies[name]; found {
+			return fmt.Errorf("conflicting dependencies of %s", name)
+		}
+
+		dst.WithDependenciesItem(name, d)
+	}
+
+	for name, required := range src.DependentRequired {
+		r := Schema{Required: dst.DependentRequired[name]}
+		addRequired(&r, required...)
+
+		dst.WithDependentRequiredItem(name, r.Required)
+	}
+
+	var err error
+
+	if dst.AdditionalProperties, err = mergeSchemaOrBool(dst.AdditionalProperties, src.AdditionalProperties); err != nil {
+		return fmt.Errorf("additionalProperties: %w", err)
+	}
+
+	if dst.PropertyNames, err = mergeSchemaOrBool(dst.PropertyNames, src.PropertyNames); err != nil {
+		return fmt.Errorf("propertyNames: %w", err)
+	}
+
+	dst.UnevaluatedProperties, err = mergeSchemaOrBool(dst.UnevaluatedProperties, src.UnevaluatedProperties)
+	if err != nil {
+		return fmt.Errorf("unevaluatedProperties: %w", err)
+	}
+
+	return nil
+}
+
+func mergeArrays(dst *Schema, src Schema) error {
+	if src.Items != nil {
+		switch {
+		case dst.Items == nil:
+			dst.Items = src.Items
+		case dst.Items.SchemaOrBool != nil && src.Items.SchemaOrBool != nil:
+			items, err := mergeSchemaOrBool(dst.Items.SchemaOrBool, src.Items.SchemaOrBool)
+			if err != nil {
+				return fmt.Errorf("items: %w", err)
+			}
+
+			dst.Items.SchemaOrBool = items
+		default:
+			return errors.New("conflicting items: tuple items can not be merged")
+		}
+	}
+
+	var err error
+
+	if dst.AdditionalItems, err = mergeSchemaOrBool(dst.AdditionalItems, src.AdditionalItems); err != nil {
+		return fmt.Errorf("additionalItems: %w", err)
+	}
+
+	if src.Contains != nil {
+		if dst.Contains != nil {
+			return errors.New("conflicting contains")
+		}
+
+		dst.Contains = src.Contains
+		dst.MinContains = src.MinContains
+	}
+
+	return nil
+}
+
+func mergeCompositions(dst *Schema, src Schema) error {
+	dst.AllOf = append(dst.AllOf, src.AllOf...)
+
+	if src.AnyOf != nil {
+		if dst.AnyOf != nil {
+			return errors.New("conflicting anyOf")
+		}
+
+		dst.AnyOf = src.AnyOf
+	}
+
+	if src.OneOf != nil {
+		if dst.OneOf != nil {
+			return errors.New("conflicting oneOf")
+		}
+
+		dst.OneOf = src.OneOf
+	}
+
+	if src.Not != nil {
+		if dst.Not != nil {
+			return errors.New("conflicting not")
+		}
+
+		dst.Not = src.Not
+	}
+
+	if src.If != nil || src.Then != nil || src.Else != nil {
+		if dst.If != nil || dst.Then != nil || dst.Else != nil {
+			return errors.New("conflicting if/then/else")
+		}
+
+		dst.If, dst.Then, dst.Else = src.If, src.Then, src.Else
+	}
+
+	return nil
+}
+
+// mergeSchemaOrBoolItem merges sb with existing map item.
+func mergeSchemaOrBoolItem(m map[string]SchemaOrBool, key string, sb SchemaOrBool) (SchemaOrBool, error) {
+	existing, found := m[key]
+	if !found {
+		return sb, nil
+	}
+
+	merged, err := mergeSchemaOrBool(&existing, &sb)
+	if err != nil {
+		return sb, err
+	}
+
+	return *merged, nil
+}
+
+func mergeSchemaOrBool(dst, src *SchemaOrBool) (*SchemaOrBool, error) {
+	switch {
+	case src == nil || (src.TypeBoolean != nil && *src.TypeBoolean):
+		return dst, nil
+	case dst == nil || (dst.TypeBoolean != nil && *dst.TypeBoolean):
+		return src, nil
+	case dst.TypeBoolean != nil:
+		return dst, nil
+	case src.TypeBoolean != nil:
+		return src, nil
+	case dst.TypeObject.Ref != nil && src.TypeObject.Ref != nil && *dst.TypeObject.Ref == *src.TypeObject.Ref:
+		return dst, nil
+	}
+
+	if err := Merge(dst.TypeObject, src.TypeObject); err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+func maxFloat(a, b *float64) *float64 {
+	if a == nil || (b != nil && *b > *a) {
+		return b
+	}
+
+	return a
+}
+
+func minFloat(a, b *float64) *float64 {
+	if a == nil || (b != nil && *b < *a) {
+		return b
+	}
+
+	return a
+}
+
+func minInt(a, b *int64) *int64 {
+	if a == nil || (b != nil && *b < *a) {
+		return b
+	}
+
+	return a
+}
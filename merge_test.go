@@ -0,0 +1,97 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestMerge(t *testing.T) {
+	parse := func(s string) jsonschema.Schema {
+		var r jsonschema.Schema
+
+		require.NoError(t, json.Unmarshal([]byte(s), &r))
+
+		return r
+	}
+
+	dst := parse(`{
+	  "title":"dst","type":["number","null"],"minimum":1,"maximum":10,"enum":[1,2,3],"multipleOf":0.5,
+	  "properties":{"a":{"type":"string","minLength":1}},"required":["a"]
+	}`)
+	src := parse(`{
+	  "title":"src","description":"src","type":"integer","minimum":0,"maximum":5,"enum":[2,3,4],"multipleOf":1,
+	  "properties":{"a":{"maxLength":5},"b":{"type":"boolean"}},"required":["b","a"]
+	}`)
+
+	require.NoError(t, jsonschema.Merge(&dst, &src))
+	assertjson.EqMarshal(t, `{
+	  "title":"dst","description":"src","type":"integer","minimum":1,"maximum":5,"enum":[2,3],"multipleOf":1,
+	  "properties":{"a":{"type":"string","minLength":1,"maxLength":5},"b":{"type":"boolean"}},
+	  "required":["a","b"]
+	}`, dst)
+
+	// Source is not modified.
+	assertjson.EqMarshal(t, `{
+	  "title":"src","description":"src","type":"integer","minimum":0,"maximum":5,"enum":[2,3,4],"multipleOf":1,
+	  "properties":{"a":{"maxLength":5},"b":{"type":"boolean"}},"required":["b","a"]
+	}`, src)
+
+	for _, tc := range []struct{ dst, src, err string }{
+		{`{"type":"string"}`, `{"type":"integer"}`, "conflicting types: [string] and [integer]"},
+		{`{"enum":[1]}`, `{"enum":[2]}`, "conflicting enum: no common values"},
+		{`{"const":1}`, `{"const":2}`, "conflicting const: 1 and 2"},
+		{`{"pattern":"a"}`, `{"pattern":"b"}`, "conflicting pattern: a and b"},
+		{`{"multipleOf":2}`, `{"multipleOf":3}`, "conflicting multipleOf: 2 and 3"},
+		{`{"properties":{"a":{"type":"string"}}}`, `{"properties":{"a":{"type":"object"}}}`, "property a: conflicting types: [string] and [object]"},
+		{`{}`, `{"$ref":"#/definitions/A"}`, "can not merge reference #/definitions/A, resolve it first"},
+	} {
+		d, s := parse(tc.dst), parse(tc.src)
+		assert.EqualError(t, jsonschema.Merge(&d, &s), tc.err)
+	}
+}
+
+func TestSchema_FlattenAllOf(t *testing.T) {
+	type Base struct {
+		ID      int    `json:"id" minimum:"1" required:"true"`
+		Comment string `json:"comment"`
+	}
+
+	type Named struct {
+		Name string `json:"name" minLength:"1"`
+	}
+
+	type Entity struct {
+		Base  `refer:"true"`
+		Named `refer:"true"`
+		Tags  []string `json:"tags"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(Entity{}, jsonschema.StripDefinitionNamePrefix("JsonschemaGoTest"))
+	require.NoError(t, err)
+	require.Len(t, s.AllOf, 2)
+
+	require.NoError(t, s.FlattenAllOf())
+	s.PruneUnusedDefinitions()
+
+	assertjson.EqMarshal(t, `{
+	  "required":["id"],
+	  "properties":{
+		"comment":{"type":"string"},"id":{"minimum":1,"type":"integer"},
+		"name":{"minLength":1,"type":"string"},
+		"tags":{"items":{"type":"string"},"type":["array","null"]}
+	  },
+	  "type":"object"
+	}`, s)
+
+	var rec jsonschema.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{"definitions":{"A":{"allOf":[{"$ref":"#/definitions/A"}]}}}`), &rec))
+	assert.EqualError(t, rec.FlattenAllOf(), "definition A: allOf[0]: recursive reference #/definitions/A can not be merged")
+}
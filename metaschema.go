@@ -0,0 +1,77 @@
+package jsonschema
+
+import (
+	_ "embed" // Meta-schemas are embedded.
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Draft07Schema is the URI of JSON Schema draft-07 meta schema.
+const Draft07Schema = "http://json-schema.org/draft-07/schema#"
+
+//go:embed resources/schema/draft-07.json
+var draft07MetaSchema []byte
+
+// metaSchemas maps dialect URIs without trailing "#" to embedded meta-schemas.
+var metaSchemas = map[string][]byte{
+	strings.TrimSuffix(Draft07Schema, "#"): draft07MetaSchema,
+}
+
+// MetaSchema returns a meta-schema of dialect identified by its URI, e.g. Draft07Schema.
+//
+// Only draft-07 meta-schema is available, error is returned for other dialects.
+// Meta-schema can be used to validate JSON Schema documents with Schema.ValidateJSON.
+func MetaSchema(dialect string) (Schema, error) {
+	var s Schema
+
+	data, found := metaSchemas[strings.TrimSuffix(dialect, "#")]
+	if !found {
+		return s, fmt.Errorf("meta-schema of %s is not available", dialect)
+	}
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("failed to unmarshal meta-schema of %s: %w", dialect, err)
+	}
+
+	return s, nil
+}
+
+// SelfValidate enables validation of reflected schema and collected definitions against the meta-schema
+// of reflected dialect.
+//
+// Reflection fails with an error that lists violations, it is intended for tests and debugging of
+// interceptors and exposers that alter schema.
+func SelfValidate(rc *ReflectContext) {
+	rc.SelfValidate = true
+}
+
+func (rc *ReflectContext) selfValidate(path string, s Schema) error {
+	dialect := Draft07Schema
+
+	switch {
+	case rc.OpenAPI30 || rc.Swagger20:
+		return errors.New("self-validation is not available for OpenAPI dialects")
+	case rc.Draft201909:
+		dialect = Draft201909Schema
+	case rc.Draft202012:
+		dialect = Draft202012Schema
+	}
+
+	ms, err := MetaSchema(dialect)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	if err := ms.ValidateJSON(data); err != nil {
+		return fmt.Errorf("%s: schema does not match meta-schema: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/jsonschema-go"
+)
+
+type negativeMinProperties struct {
+	Name string `json:"name"`
+}
+
+func (negativeMinProperties) PrepareJSONSchema(s *jsonschema.Schema) error {
+	s.WithMinProperties(-1)
+
+	return nil
+}
+
+func TestMetaSchema(t *testing.T) {
+	ms, err := jsonschema.MetaSchema(jsonschema.Draft07Schema)
+	require.NoError(t, err)
+	assert.Equal(t, jsonschema.Draft07Schema, *ms.ID)
+
+	require.NoError(t, ms.ValidateJSON([]byte(`{"type":"object","properties":{"id":{"type":"integer","minimum":1}}}`)))
+	assert.Error(t, ms.ValidateJSON([]byte(`{"type":"unknown"}`)))
+	assert.Error(t, ms.ValidateJSON([]byte(`{"required":"id"}`)))
+
+	_, err = jsonschema.MetaSchema(jsonschema.Draft202012Schema)
+	assert.EqualError(t, err, "meta-schema of https://json-schema.org/draft/2020-12/schema is not available")
+}
+
+func TestSelfValidate(t *testing.T) {
+	type Order struct {
+		ID     int                   `json:"id" minimum:"1" required:"true"`
+		Tags   []string              `json:"tags" uniqueItems:"true"`
+		Parent *Order                `json:"parent"`
+		Extra  negativeMinProperties `json:"extra"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	_, err := r.Reflect(Order{}, jsonschema.SelfValidate)
+	assert.EqualError(t, err, "#: schema does not match meta-schema: "+
+		"#/definitions/JsonschemaGoTestNegativeMinProperties/minProperties: -1 is less than 0")
+
+	_, err = r.Reflect(Order{}, jsonschema.SelfValidate, jsonschema.CollectDefinitions(func(_ string, _ jsonschema.Schema) {}))
+	assert.EqualError(t, err, "#/definitions/JsonschemaGoTestNegativeMinProperties: "+
+		"schema does not match meta-schema: #/minProperties: -1 is less than 0")
+
+	type Item struct {
+		ID     int               `json:"id" minimum:"1" required:"true"`
+		Kind   string            `json:"kind" enum:"a,b" default:"a"`
+		Tags   []string          `json:"tags" uniqueItems:"true" maxItems:"3"`
+		Parent *Item             `json:"parent"`
+		Meta   map[string]string `json:"meta" pattern:"^[a-z]+$"`
+	}
+
+	_, err = r.Reflect(Item{}, jsonschema.SelfValidate)
+	assert.NoError(t, err)
+}
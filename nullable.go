@@ -0,0 +1,69 @@
+package jsonschema
+
+import (
+	"path"
+	"reflect"
+	"strings"
+)
+
+// nullableWrapper returns type of value of a nullable wrapper struct (e.g. null.String of gopkg.in/guregu/null)
+// and whether wrapper is nullable in JSON.
+//
+// Wrapper is a struct that implements json.Marshaler and has exported Valid bool field and a value field,
+// possibly in a single embedded struct (e.g. sql.NullString). Types of guregu/null packages are always recognized,
+// types of their zero package are not nullable as invalid values are marshaled as zero values.
+// Other types are recognized with InferNullableWrappers.
+func nullableWrapper(rc *ReflectContext, t reflect.Type) (value reflect.Type, nullable bool, ok bool) {
+	if t.Kind() != reflect.Struct {
+		return nil, false, false
+	}
+
+	pkg := t.PkgPath()
+	guregu := strings.HasPrefix(pkg, "gopkg.in/guregu/null.v") || strings.HasPrefix(pkg, "github.com/guregu/null")
+
+	if !guregu && !rc.InferNullableWrappers {
+		return nil, false, false
+	}
+
+	if !t.Implements(typeOfJSONMarshaler) && !reflect.PtrTo(t).Implements(typeOfJSONMarshaler) {
+		return nil, false, false
+	}
+
+	value = wrappedValue(t)
+	if value == nil {
+		return nil, false, false
+	}
+
+	return value, !guregu || path.Base(pkg) != "zero", true
+}
+
+// wrappedValue returns type of value field of a struct with Valid bool and value fields.
+func wrappedValue(t reflect.Type) reflect.Type {
+	var (
+		value reflect.Type
+		valid bool
+	)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		switch {
+		case f.Anonymous && f.Type.Kind() == reflect.Struct && t.NumField() == 1:
+			return wrappedValue(f.Type)
+		case f.PkgPath != "":
+			return nil
+		case f.Name == "Valid" && f.Type.Kind() == reflect.Bool:
+			valid = true
+		case value == nil:
+			value = f.Type
+		default:
+			return nil
+		}
+	}
+
+	if !valid {
+		return nil
+	}
+
+	return value
+}
@@ -0,0 +1,328 @@
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Warning describes a lossy change made by dialect conversion.
+type Warning struct {
+	// Path is a JSON Pointer of changed schema, e.g. "#/properties/foo".
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// String implements fmt.Stringer.
+func (w Warning) String() string {
+	return w.Path + ": " + w.Message
+}
+
+// OpenAPI30 enables reflection of OpenAPI 3.0 schema dialect, see Schema.ConvertOpenAPI30.
+//
+// Conversion warnings are reported to ReflectContext.CollectWarnings if it is set.
+func OpenAPI30(rc *ReflectContext) {
+	rc.OpenAPI30 = true
+}
+
+// Swagger20 enables reflection of Swagger 2.0 schema dialect, see Schema.ConvertSwagger20.
+//
+// Conversion warnings are reported to ReflectContext.CollectWarnings if it is set.
+func Swagger20(rc *ReflectContext) {
+	rc.Swagger20 = true
+}
+
+// CollectWarnings enables collecting lossy changes made by dialect conversion (OpenAPI30 or Swagger20).
+func CollectWarnings(f func(w Warning)) func(*ReflectContext) {
+	return func(rc *ReflectContext) {
+		rc.CollectWarnings = f
+	}
+}
+
+// ConvertOpenAPI30 converts schema and its subschemas to OpenAPI 3.0 dialect (an extended subset of draft-04).
+//
+// Conversion is done in place:
+//   - null type is replaced with `nullable: true`, multiple types are replaced with `anyOf`,
+//   - `anyOf` with `{"type":"null"}` item (see ReflectContext.EnvelopNullability) is replaced with
+//     `nullable: true` and `allOf` of remaining reference,
+//   - `examples` are replaced with single `example`, `const` is replaced with `enum`,
+//   - exclusive bounds are converted to draft-04 boolean style,
+//   - tuple `items` are replaced with `anyOf` of tuple items,
+//   - keywords unsupported by OpenAPI 3.0 are dropped: `$schema`, `$id`, `$comment`, `contains`, `propertyNames`,
+//     `if`, `then`, `else`, `dependencies`, `additionalItems`, `patternProperties`, `unevaluatedProperties`,
+//     `contentMediaType` and `contentEncoding`.
+//
+// New keywords are stored in Schema.ExtraProperties, so converted schema should only be used for marshaling.
+// Warnings describe lossy changes that allow values that would be invalid for the original schema.
+func (s *Schema) ConvertOpenAPI30() []Warning {
+	return convertOpenAPI30("#", s)
+}
+
+func convertOpenAPI30(path string, s *Schema) []Warning {
+	c := openAPIConverter{nullable: "nullable"}
+	c.convert(path, s)
+
+	return c.sortedWarnings()
+}
+
+// ConvertSwagger20 converts schema and its subschemas to Swagger 2.0 (OpenAPI 2) dialect.
+//
+// Conversion is done in place, similar to ConvertOpenAPI30 with these differences:
+//   - nullability is expressed with `x-nullable: true` vendor extension,
+//   - `anyOf` and `oneOf` are replaced with the loosest common schema (type that is shared by all items, if any),
+//   - multiple types are removed, `not` is dropped.
+//
+// Warnings describe lossy changes that allow values that would be invalid for the original schema.
+func (s *Schema) ConvertSwagger20() []Warning {
+	return convertSwagger20("#", s)
+}
+
+func convertSwagger20(path string, s *Schema) []Warning {
+	c := openAPIConverter{nullable: "x-nullable", swagger20: true}
+	c.convert(path, s)
+
+	return c.sortedWarnings()
+}
+
+type openAPIConverter struct {
+	nullable  string
+	swagger20 bool
+	warnings  []Warning
+}
+
+func (c *openAPIConverter) sortedWarnings() []Warning {
+	sort.SliceStable(c.warnings, func(i, j int) bool {
+		return c.warnings[i].Path < c.warnings[j].Path
+	})
+
+	return c.warnings
+}
+
+func (c *openAPIConverter) warn(path, format string, args ...interface{}) {
+	c.warnings = append(c.warnings, Warning{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *openAPIConverter) convert(path string, s *Schema) {
+	c.convertNullEnvelope(s)
+
+	s.eachSubSchema(func(ptr string, sub *Schema) {
+		c.convert(path+ptr, sub)
+	})
+
+	for name, def := range s.Definitions {
+		if def.TypeObject != nil {
+			c.convert(path+"/definitions/"+escapePointer(name), def.TypeObject)
+		}
+	}
+
+	c.convertType(path, s)
+
+	if len(s.Examples) > 0 {
+		s.WithExtraPropertiesItem("example", s.Examples[0])
+		s.Examples = nil
+	}
+
+	if s.Const != nil {
+		if len(s.Enum) == 0 {
+			s.WithEnum(*s.Const)
+		}
+
+		s.Const = nil
+	}
+
+	exclusiveBoundsDraft04(s)
+
+	if s.Items != nil && s.Items.SchemaArray != nil {
+		items := (&Schema{}).WithAnyOf(s.Items.SchemaArray...)
+
+		if c.swagger20 {
+			c.convertComposition(path+"/items", items)
+		}
+
+		s.Items = (&Items{}).WithSchemaOrBool(items.ToSchemaOrBool())
+	}
+
+	if c.swagger20 {
+		c.convertComposition(path, s)
+	}
+
+	c.dropUnsupported(path, s)
+}
+
+func (c *openAPIConverter) dropUnsupported(path string, s *Schema) {
+	s.Schema = nil
+	s.ID = nil
+	s.Comment = nil
+	s.Anchor = nil
+	s.DynamicAnchor = nil
+	s.ContentMediaType = nil
+	s.ContentEncoding = nil
+
+	for _, k := range []struct {
+		keyword string
+		dropped bool
+	}{
+		{keyword: "additionalItems", dropped: s.AdditionalItems != nil},
+		{keyword: "contains", dropped: s.Contains != nil},
+		{keyword: "minContains", dropped: s.MinContains != nil},
+		{keyword: "$dynamicRef", dropped: s.DynamicRef != nil},
+		{keyword: "$recursiveRef", dropped: s.RecursiveRef != nil},
+		{keyword: "dependencies", dropped: len(s.Dependencies) > 0},
+		{keyword: "dependentRequired", dropped: len(s.DependentRequired) > 0},
+		{keyword: "if", dropped: s.If != nil},
+		{keyword: "then", dropped: s.Then != nil},
+		{keyword: "else", dropped: s.Else != nil},
+		{keyword: "not", dropped: c.swagger20 && s.Not != nil},
+		{keyword: "patternProperties", dropped: len(s.PatternProperties) > 0},
+		{keyword: "propertyNames", dropped: s.PropertyNames != nil},
+		{keyword: "unevaluatedProperties", dropped: s.UnevaluatedProperties != nil},
+	} {
+		if k.dropped {
+			c.warn(path, "%s is not supported and was removed", k.keyword)
+		}
+	}
+
+	s.Contains = nil
+	s.MinContains = nil
+	s.DynamicRef = nil
+	s.RecursiveRef = nil
+	s.PropertyNames = nil
+	s.If = nil
+	s.Then = nil
+	s.Else = nil
+	s.Dependencies = nil
+	s.DependentRequired = nil
+	s.AdditionalItems = nil
+	s.PatternProperties = nil
+	s.UnevaluatedProperties = nil
+
+	if c.swagger20 {
+		s.Not = nil
+	}
+}
+
+func (c *openAPIConverter) convertType(path string, s *Schema) {
+	if s.Type == nil {
+		return
+	}
+
+	if s.HasType(Null) {
+		s.RemoveType(Null)
+		s.WithExtraPropertiesItem(c.nullable, true)
+	}
+
+	types := s.simpleTypes()
+
+	switch len(types) {
+	case 0:
+		s.Type = nil
+	case 1:
+		s.WithType(types[0].Type())
+	default:
+		s.Type = nil
+
+		if c.swagger20 {
+			c.warn(path, "multiple types %v are not supported and were removed", types)
+
+			return
+		}
+
+		anyOf := make([]SchemaOrBool, 0, len(types))
+
+		for _, t := range types {
+			anyOf = append(anyOf, t.ToSchemaOrBool())
+		}
+
+		if len(s.AnyOf) == 0 {
+			s.AnyOf = anyOf
+		} else {
+			s.AllOf = append(s.AllOf, (&Schema{}).WithAnyOf(anyOf...).ToSchemaOrBool())
+		}
+	}
+}
+
+// convertComposition replaces `anyOf` and `oneOf` with the loosest common schema.
+func (c *openAPIConverter) convertComposition(path string, s *Schema) {
+	for _, k := range []struct {
+		keyword string
+		items   []SchemaOrBool
+	}{
+		{keyword: "anyOf", items: s.AnyOf},
+		{keyword: "oneOf", items: s.OneOf},
+	} {
+		keyword, items := k.keyword, k.items
+
+		if len(items) == 0 {
+			continue
+		}
+
+		var common *SimpleType
+
+		for i, item := range items {
+			types := []SimpleType(nil)
+			if item.TypeObject != nil && item.TypeObject.Ref == nil {
+				types = item.TypeObject.simpleTypes()
+			}
+
+			if len(types) != 1 || (i > 0 && (common == nil || *common != types[0])) {
+				common = nil
+
+				break
+			}
+
+			common = &types[0]
+		}
+
+		if common != nil && s.Type == nil {
+			s.WithType(common.Type())
+			c.warn(path, "%s is not supported and was replaced with type %s", keyword, *common)
+		} else {
+			c.warn(path, "%s is not supported and was removed", keyword)
+		}
+	}
+
+	s.AnyOf = nil
+	s.OneOf = nil
+}
+
+// convertNullEnvelope replaces `"anyOf":[{"type":"null"},{"$ref":"..."}]` with nullable flag.
+func (c *openAPIConverter) convertNullEnvelope(s *Schema) {
+	if len(s.AnyOf) < 2 {
+		return
+	}
+
+	var (
+		nullable bool
+		anyOf    []SchemaOrBool
+	)
+
+	for _, item := range s.AnyOf {
+		if item.TypeObject != nil && item.TypeObject.HasType(Null) && len(item.TypeObject.simpleTypes()) == 1 &&
+			item.TypeObject.IsTrivial() {
+			nullable = true
+
+			continue
+		}
+
+		anyOf = append(anyOf, item)
+	}
+
+	if !nullable {
+		return
+	}
+
+	s.WithExtraPropertiesItem(c.nullable, true)
+
+	if len(anyOf) != 1 || anyOf[0].TypeObject == nil {
+		s.AnyOf = anyOf
+
+		return
+	}
+
+	s.AnyOf = nil
+
+	if anyOf[0].TypeObject.Ref != nil {
+		s.AllOf = append(s.AllOf, anyOf[0])
+	} else {
+		mergeSchema(s, *anyOf[0].TypeObject)
+	}
+}
@@ -0,0 +1,175 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestSchema_ConvertOpenAPI30(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+	  "$schema":"http://json-schema.org/draft-07/schema#",
+	  "type":"object",
+	  "properties":{
+		"name":{"type":["string","null"],"examples":["Jane","John"]},
+		"kind":{"const":"user"},
+		"score":{"type":"number","exclusiveMinimum":0,"exclusiveMaximum":10},
+		"value":{"type":["string","integer"]},
+		"parent":{"anyOf":[{"type":"null"},{"$ref":"#/definitions/User"}]},
+		"meta":{"anyOf":[{"type":"null"},{"type":"object","additionalProperties":{"type":"string"}}]},
+		"point":{"type":"array","items":[{"type":"number"},{"type":"string"}],"additionalItems":false},
+		"tags":{"type":"array","items":{"type":"string"},"contains":{"const":"a"}}
+	  },
+	  "patternProperties":{"^x-":{}},
+	  "propertyNames":{"maxLength":10},
+	  "definitions":{"User":{"type":["object","null"],"if":{"required":["a"]},"then":{"required":["b"]}}}
+	}`)))
+
+	s.ConvertOpenAPI30()
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{"User":{"type":"object","nullable":true}},
+	  "properties":{
+		"kind":{"enum":["user"]},
+		"meta":{
+		  "additionalProperties":{"type":"string"},"type":"object","nullable":true
+		},
+		"name":{"type":"string","example":"Jane","nullable":true},
+		"parent":{"allOf":[{"$ref":"#/definitions/User"}],"nullable":true},
+		"point":{"items":{"anyOf":[{"type":"number"},{"type":"string"}]},"type":"array"},
+		"score":{
+		  "maximum":10,"minimum":0,"type":"number","exclusiveMaximum":true,
+		  "exclusiveMinimum":true
+		},
+		"tags":{"items":{"type":"string"},"type":"array"},
+		"value":{"anyOf":[{"type":"string"},{"type":"integer"}]}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestOpenAPI30(t *testing.T) {
+	type Item struct {
+		Name string `json:"name" examples:"[\"foo\",\"bar\"]"`
+	}
+
+	type Order struct {
+		Item  *Item   `json:"item"`
+		Items []Item  `json:"items"`
+		Note  *string `json:"note"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(Order{}, jsonschema.OpenAPI30, func(rc *jsonschema.ReflectContext) {
+		rc.EnvelopNullability = true
+	})
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestItem":{
+		  "properties":{"name":{"type":"string","example":"foo"}},"type":"object"
+		}
+	  },
+	  "properties":{
+		"item":{"allOf":[{"$ref":"#/definitions/JsonschemaGoTestItem"}],"nullable":true},
+		"items":{
+		  "items":{"$ref":"#/definitions/JsonschemaGoTestItem"},"type":"array",
+		  "nullable":true
+		},
+		"note":{"type":"string","nullable":true}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestSchema_ConvertSwagger20(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+	  "type":"object",
+	  "properties":{
+		"name":{"type":["string","null"],"examples":["Jane"]},
+		"id":{"oneOf":[{"type":"integer","minimum":1},{"type":"integer","maximum":-1}]},
+		"value":{"type":["string","integer"]},
+		"any":{"anyOf":[{"type":"string"},{"$ref":"#/definitions/User"}]},
+		"parent":{"anyOf":[{"type":"null"},{"$ref":"#/definitions/User"}]},
+		"score":{"type":"number","exclusiveMinimum":0},
+		"point":{"type":"array","items":[{"type":"number"},{"type":"number"}]}
+	  },
+	  "definitions":{"User":{"type":"object","not":{"required":["password"]}}}
+	}`)))
+
+	warnings := s.ConvertSwagger20()
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{"User":{"type":"object"}},
+	  "properties":{
+		"any":{},"id":{"type":"integer"},
+		"name":{"type":"string","example":"Jane","x-nullable":true},
+		"parent":{"allOf":[{"$ref":"#/definitions/User"}],"x-nullable":true},
+		"point":{"items":{"type":"number"},"type":"array"},
+		"score":{"minimum":0,"type":"number","exclusiveMinimum":true},
+		"value":{}
+	  },
+	  "type":"object"
+	}`, s)
+
+	assertjson.EqMarshal(t, `[
+	  {"path":"#/definitions/User","message":"not is not supported and was removed"},
+	  {"path":"#/properties/any","message":"anyOf is not supported and was removed"},
+	  {"path":"#/properties/id","message":"oneOf is not supported and was replaced with type integer"},
+	  {
+		"path":"#/properties/point/items",
+		"message":"anyOf is not supported and was replaced with type number"
+	  },
+	  {
+		"path":"#/properties/value",
+		"message":"multiple types [string integer] are not supported and were removed"
+	  }
+	]`, warnings)
+}
+
+func TestSwagger20(t *testing.T) {
+	type Pet struct {
+		Name string `json:"name" minLength:"1"`
+	}
+
+	type Owner struct {
+		Pet  *Pet    `json:"pet"`
+		Tags []Pet   `json:"tags"`
+		Note *string `json:"note"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	var warnings []string
+
+	s, err := r.Reflect(Owner{}, jsonschema.Swagger20, jsonschema.CollectWarnings(func(w jsonschema.Warning) {
+		warnings = append(warnings, w.String())
+	}), func(rc *jsonschema.ReflectContext) {
+		rc.EnvelopNullability = true
+	})
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestPet":{"properties":{"name":{"minLength":1,"type":"string"}},"type":"object"}
+	  },
+	  "properties":{
+		"note":{"type":"string","x-nullable":true},
+		"pet":{"allOf":[{"$ref":"#/definitions/JsonschemaGoTestPet"}],"x-nullable":true},
+		"tags":{
+		  "items":{"$ref":"#/definitions/JsonschemaGoTestPet"},"type":"array",
+		  "x-nullable":true
+		}
+	  },
+	  "type":"object"
+	}`, s)
+}
@@ -0,0 +1,64 @@
+package jsonschema
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// ReflectAll reflects schemas of multiple values concurrently with a bounded pool of workers.
+//
+// Each value is reflected as with a separate Reflect call, schemas are returned in the order of values.
+// If workers is not positive, runtime.GOMAXPROCS(0) workers are used. Reflection state shared between
+// calls (definition names, CacheDefinitions) is synchronized, so with CacheDefinitions definitions of
+// common models are reflected once and reused by other workers.
+//
+// Reflector must not be configured (e.g. with AddTypeMapping) during ReflectAll and options with
+// functions (e.g. InterceptSchema, CollectDefinitions) must be safe for concurrent use.
+// Definition names of different types with the same name (e.g. "MyTypeType2") depend on the order of
+// reflection, which is not deterministic.
+//
+// First error (in the order of values) is returned.
+func (r *Reflector) ReflectAll(workers int, values []interface{}, options ...func(rc *ReflectContext)) ([]Schema, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if workers > len(values) {
+		workers = len(values)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		schemas = make([]Schema, len(values))
+		errs    = make([]error, len(values))
+		jobs    = make(chan int)
+	)
+
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				schemas[i], errs[i] = r.Reflect(values[i], options...)
+			}
+		}()
+	}
+
+	for i := range values {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("reflecting %T: %w", values[i], err)
+		}
+	}
+
+	return schemas, nil
+}
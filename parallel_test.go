@@ -0,0 +1,45 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestReflector_ReflectAll(t *testing.T) {
+	type Item struct {
+		Name string `json:"name"`
+	}
+
+	type Order struct {
+		Items []Item `json:"items"`
+	}
+
+	type Cart struct {
+		Items []Item `json:"items"`
+		Order *Order `json:"order"`
+	}
+
+	values := []interface{}{Order{}, Cart{}, Item{}, new(Cart), []Order{}}
+
+	for _, cache := range []bool{false, true} {
+		r := jsonschema.Reflector{CacheDefinitions: cache}
+
+		schemas, err := r.ReflectAll(3, values, jsonschema.RootRef)
+		require.NoError(t, err)
+		require.Len(t, schemas, len(values))
+
+		for i, v := range values {
+			expected, err := (&jsonschema.Reflector{}).Reflect(v, jsonschema.RootRef)
+			require.NoError(t, err)
+			assert.True(t, expected.Equal(schemas[i]), i)
+		}
+	}
+
+	r := jsonschema.Reflector{}
+
+	_, err := r.ReflectAll(0, []interface{}{Item{}, make(chan int)})
+	assert.EqualError(t, err, "reflecting chan int: : type is not supported: chan int")
+}
@@ -0,0 +1,57 @@
+package jsonschema
+
+// PartialOptions configures MakePartial.
+type PartialOptions struct {
+	// NullableProperties adds null type to properties, so that null can be used to remove a value
+	// as in JSON Merge Patch (RFC 7396).
+	NullableProperties bool
+}
+
+// PartialNullable adds null type to properties of partial schema.
+func PartialNullable(po *PartialOptions) {
+	po.NullableProperties = true
+}
+
+// MakePartial transforms object schema into a variant suitable for PATCH requests,
+// where any subset of properties can be provided.
+//
+// Required properties are cleared recursively for inline object properties. Array items and
+// referenced definitions are kept intact, as they are shared with complete schemas and
+// arrays are replaced as a whole by JSON Merge Patch.
+//
+// Schema is changed in place, use Clone to keep original schema.
+func MakePartial(s *Schema, options ...func(po *PartialOptions)) {
+	po := PartialOptions{}
+
+	for _, option := range options {
+		option(&po)
+	}
+
+	makePartial(s, po)
+}
+
+func makePartial(s *Schema, po PartialOptions) {
+	s.Required = nil
+
+	for _, prop := range s.Properties {
+		p := prop.TypeObject
+		if p == nil {
+			continue
+		}
+
+		if p.HasType(Object) {
+			makePartial(p, po)
+		}
+
+		if !po.NullableProperties {
+			continue
+		}
+
+		switch {
+		case p.Ref != nil:
+			envelopNull(p)
+		case p.Type != nil:
+			p.AddType(Null)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestMakePartial(t *testing.T) {
+	type Address struct {
+		City string `json:"city" required:"true"`
+	}
+
+	type User struct {
+		Name    string   `json:"name" required:"true" minLength:"1"`
+		Age     int      `json:"age,omitempty" required:"true"`
+		Tags    []string `json:"tags,omitempty"`
+		Address Address  `json:"address" required:"true"`
+		Profile struct {
+			Bio  string `json:"bio" required:"true"`
+			Meta struct {
+				Source string `json:"source" required:"true"`
+			} `json:"meta"`
+		} `json:"profile"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(User{})
+	require.NoError(t, err)
+
+	full := s.Clone()
+	jsonschema.MakePartial(&s)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestAddress":{
+		  "required":["city"],"properties":{"city":{"type":"string"}},"type":"object"
+		}
+	  },
+	  "properties":{
+		"address":{"$ref":"#/definitions/JsonschemaGoTestAddress"},
+		"age":{"type":"integer"},"name":{"minLength":1,"type":"string"},
+		"profile":{
+		  "properties":{
+			"bio":{"type":"string"},
+			"meta":{"properties":{"source":{"type":"string"}},"type":"object"}
+		  },
+		  "type":"object"
+		},
+		"tags":{"items":{"type":"string"},"type":"array"}
+	  },
+	  "type":"object"
+	}`, s)
+
+	jsonschema.MakePartial(&full, jsonschema.PartialNullable)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestAddress":{
+		  "required":["city"],"properties":{"city":{"type":"string"}},"type":"object"
+		}
+	  },
+	  "properties":{
+		"address":{"anyOf":[{"type":"null"},{"$ref":"#/definitions/JsonschemaGoTestAddress"}]},
+		"age":{"type":["integer","null"]},"name":{"minLength":1,"type":["string","null"]},
+		"profile":{
+		  "properties":{
+			"bio":{"type":["string","null"]},
+			"meta":{"properties":{"source":{"type":["string","null"]}},"type":["object","null"]}
+		  },
+		  "type":["object","null"]
+		},
+		"tags":{"items":{"type":"string"},"type":["array","null"]}
+	  },
+	  "type":"object"
+	}`, full)
+}
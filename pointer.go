@@ -0,0 +1,148 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AtPointer returns subschema addressed by JSON Pointer, e.g. "/properties/foo/items/0".
+//
+// Leading "#" is optional, empty pointer addresses the Schema itself. References are not followed.
+// Returned subschema is not a copy, so it can be used to patch reflected schema in place.
+func (s *Schema) AtPointer(ptr string) (*Schema, error) {
+	ptr = strings.TrimPrefix(ptr, "#")
+	if ptr == "" {
+		return s, nil
+	}
+
+	if !strings.HasPrefix(ptr, "/") {
+		return nil, fmt.Errorf("invalid JSON Pointer %q: must start with /", ptr)
+	}
+
+	tokens := strings.Split(ptr[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+
+	cur := s
+
+	for i := 0; i < len(tokens); i++ {
+		keyword := tokens[i]
+		path := "/" + strings.Join(tokens[:i+1], "/")
+
+		var (
+			sb   *SchemaOrBool
+			next = func() (string, error) {
+				if i+1 >= len(tokens) {
+					return "", fmt.Errorf("%s: missing reference token", path)
+				}
+
+				i++
+				path += "/" + tokens[i]
+
+				return tokens[i], nil
+			}
+		)
+
+		switch keyword {
+		case "properties", "patternProperties", "definitions", "dependencies":
+			name, err := next()
+			if err != nil {
+				return nil, err
+			}
+
+			sb = cur.namedSubSchema(keyword, name)
+		case "items":
+			switch {
+			case cur.Items == nil:
+			case cur.Items.SchemaOrBool != nil:
+				sb = cur.Items.SchemaOrBool
+			default:
+				idx, err := next()
+				if err != nil {
+					return nil, err
+				}
+
+				sb = indexedSubSchema(cur.Items.SchemaArray, idx)
+			}
+		case "allOf", "anyOf", "oneOf":
+			idx, err := next()
+			if err != nil {
+				return nil, err
+			}
+
+			sb = indexedSubSchema(map[string][]SchemaOrBool{
+				"allOf": cur.AllOf,
+				"anyOf": cur.AnyOf,
+				"oneOf": cur.OneOf,
+			}[keyword], idx)
+		case "additionalItems":
+			sb = cur.AdditionalItems
+		case "contains":
+			sb = cur.Contains
+		case "additionalProperties":
+			sb = cur.AdditionalProperties
+		case "propertyNames":
+			sb = cur.PropertyNames
+		case "if":
+			sb = cur.If
+		case "then":
+			sb = cur.Then
+		case "else":
+			sb = cur.Else
+		case "not":
+			sb = cur.Not
+		case "unevaluatedProperties":
+			sb = cur.UnevaluatedProperties
+		default:
+			return nil, fmt.Errorf("%s: unsupported keyword %s", path, keyword)
+		}
+
+		if sb == nil {
+			return nil, fmt.Errorf("%s: schema not found", path)
+		}
+
+		if sb.TypeObject == nil {
+			return nil, fmt.Errorf("%s: boolean schema", path)
+		}
+
+		cur = sb.TypeObject
+	}
+
+	return cur, nil
+}
+
+func (s *Schema) namedSubSchema(keyword, name string) *SchemaOrBool {
+	var m map[string]SchemaOrBool
+
+	switch keyword {
+	case "properties":
+		m = s.Properties
+	case "patternProperties":
+		m = s.PatternProperties
+	case "definitions":
+		m = s.Definitions
+	case "dependencies":
+		if d, found := s.Dependencies[name]; found {
+			return d.SchemaOrBool
+		}
+
+		return nil
+	}
+
+	if sb, found := m[name]; found {
+		return &sb
+	}
+
+	return nil
+}
+
+func indexedSubSchema(items []SchemaOrBool, idx string) *SchemaOrBool {
+	i, err := strconv.Atoi(idx)
+	if err != nil || i < 0 || i >= len(items) {
+		return nil
+	}
+
+	return &items[i]
+}
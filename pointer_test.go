@@ -0,0 +1,65 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestSchema_AtPointer(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+		"type":"object",
+		"properties":{
+			"foo":{"type":"array","items":[{"type":"string"},{"type":"integer"}]},
+			"a/b":{"type":"array","items":{"type":"boolean"}},
+			"bar":{"anyOf":[{"type":"null"},{"$ref":"#/definitions/Bar"}]},
+			"baz":true
+		},
+		"definitions":{"Bar":{"type":"object","additionalProperties":{"type":"number"}}}
+	}`)))
+
+	for ptr, expected := range map[string]string{
+		"":                                      `{"type":"object"}`,
+		"#":                                     `{"type":"object"}`,
+		"/properties/foo/items/1":               `{"type":"integer"}`,
+		"#/properties/a~1b/items":               `{"type":"boolean"}`,
+		"/properties/bar/anyOf/1":               `{"$ref":"#/definitions/Bar"}`,
+		"/definitions/Bar/additionalProperties": `{"type":"number"}`,
+	} {
+		ss, err := s.AtPointer(ptr)
+		require.NoError(t, err, ptr)
+
+		if ptr == "" || ptr == "#" {
+			assert.Same(t, &s, ss)
+
+			continue
+		}
+
+		assertjson.EqualMarshal(t, []byte(expected), ss, ptr)
+	}
+
+	// Patching in place.
+	ss, err := s.AtPointer("/properties/foo/items/0")
+	require.NoError(t, err)
+	ss.WithFormat("date")
+
+	assertjson.EqualMarshal(t, []byte(`{"type":"string","format":"date"}`), s.Properties["foo"].TypeObject.Items.SchemaArray[0])
+
+	for ptr, msg := range map[string]string{
+		"properties/foo":          `invalid JSON Pointer "properties/foo": must start with /`,
+		"/properties/qux":         "/properties/qux: schema not found",
+		"/properties":             "/properties: missing reference token",
+		"/properties/baz":         "/properties/baz: boolean schema",
+		"/properties/foo/items/5": "/properties/foo/items/5: schema not found",
+		"/properties/foo/type":    "/properties/foo/type: unsupported keyword type",
+		"/definitions/Bar/not":    "/definitions/Bar/not: schema not found",
+	} {
+		_, err := s.AtPointer(ptr)
+		assert.EqualError(t, err, msg, ptr)
+	}
+}
@@ -0,0 +1,62 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/refl"
+)
+
+const protoKnownTypesPkg = "google.golang.org/protobuf/types/known/"
+
+// protoWellKnownTypes maps protobuf well-known types to schemas of their protojson representations.
+var protoWellKnownTypes = map[refl.TypeString]func(schema *Schema){
+	protoKnownTypesPkg + "timestamppb.Timestamp": func(schema *Schema) {
+		schema.AddType(String)
+		schema.WithFormat("date-time")
+	},
+	protoKnownTypesPkg + "durationpb.Duration": func(schema *Schema) {
+		schema.AddType(String)
+		schema.WithPattern(`^-?[0-9]+(\.[0-9]{1,9})?s$`)
+		schema.WithExamples("1.5s")
+	},
+	protoKnownTypesPkg + "wrapperspb.StringValue": protoWrapper(String, ""),
+	protoKnownTypesPkg + "wrapperspb.BytesValue": func(schema *Schema) {
+		protoWrapper(String, "")(schema)
+		schema.WithFormat("base64")
+	},
+	protoKnownTypesPkg + "wrapperspb.BoolValue":   protoWrapper(Boolean, ""),
+	protoKnownTypesPkg + "wrapperspb.Int32Value":  protoWrapper(Integer, ""),
+	protoKnownTypesPkg + "wrapperspb.UInt32Value": protoWrapper(Integer, ""),
+	protoKnownTypesPkg + "wrapperspb.Int64Value":  protoWrapper(String, `^-?[0-9]+$`),
+	protoKnownTypesPkg + "wrapperspb.UInt64Value": protoWrapper(String, `^[0-9]+$`),
+	protoKnownTypesPkg + "wrapperspb.FloatValue":  protoWrapper(Number, ""),
+	protoKnownTypesPkg + "wrapperspb.DoubleValue": protoWrapper(Number, ""),
+	protoKnownTypesPkg + "structpb.Struct": func(schema *Schema) {
+		schema.AddType(Object)
+	},
+	protoKnownTypesPkg + "structpb.ListValue": func(schema *Schema) {
+		schema.AddType(Array)
+	},
+	protoKnownTypesPkg + "structpb.Value": func(schema *Schema) {
+		// Any JSON value, types are listed explicitly to keep schema intact when null is added for pointers.
+		schema.Type = &Type{SliceOfSimpleTypeValues: []SimpleType{Array, Boolean, Null, Number, Object, String}}
+	},
+}
+
+// protoWrapper describes nullable scalar of wrapperspb, 64-bit integers are represented as strings in protojson.
+func protoWrapper(t SimpleType, pattern string) func(schema *Schema) {
+	return func(schema *Schema) {
+		schema.AddType(t)
+		schema.AddType(Null)
+
+		if pattern != "" {
+			schema.WithPattern(pattern)
+		}
+	}
+}
+
+// isProtoWellKnownType checks if type is a protobuf well-known type.
+func isProtoWellKnownType(t reflect.Type) bool {
+	return strings.HasPrefix(t.PkgPath(), protoKnownTypesPkg) && protoWellKnownTypes[refl.GoType(t)] != nil
+}
@@ -0,0 +1,25 @@
+module github.com/swaggest/jsonschema-go/protoschema
+
+go 1.18
+
+require (
+	github.com/stretchr/testify v1.8.2
+	github.com/swaggest/assertjson v1.9.0
+	github.com/swaggest/jsonschema-go v0.3.70
+	google.golang.org/protobuf v1.27.1
+)
+
+require (
+	github.com/bool64/shared v0.1.5 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/iancoleman/orderedmap v0.3.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/sergi/go-diff v1.3.1 // indirect
+	github.com/swaggest/refl v1.3.0 // indirect
+	github.com/yudai/gojsondiff v1.0.0 // indirect
+	github.com/yudai/golcs v0.0.0-20170316035057-ecda9a501e82 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Package is developed along with the root module.
+replace github.com/swaggest/jsonschema-go => ../
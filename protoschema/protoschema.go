@@ -0,0 +1,311 @@
+// Package protoschema reflects JSON Schema of protobuf messages as they are represented by protojson.
+//
+// Schema is built from protoreflect descriptors, so it follows JSON names of fields, oneofs and enums
+// of .proto definitions instead of Go struct internals of generated code.
+//
+//	r := protoschema.Reflector{}
+//
+//	s, err := r.Reflect(&pb.Order{})
+package protoschema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Reflector reflects JSON Schema of protobuf messages.
+type Reflector struct {
+	// UseProtoNames enables original field names of .proto definition instead of JSON names (lowerCamelCase),
+	// as with protojson.MarshalOptions.UseProtoNames.
+	UseProtoNames bool
+
+	// UseEnumNumbers enables integer values of enums instead of names,
+	// as with protojson.MarshalOptions.UseEnumNumbers.
+	UseEnumNumbers bool
+
+	// DefinitionsPrefix defines location of message definitions, default "#/definitions/".
+	DefinitionsPrefix string
+}
+
+type reflectContext struct {
+	root        protoreflect.FullName
+	prefix      string
+	definitions map[string]jsonschema.SchemaOrBool
+}
+
+// Reflect creates JSON Schema of a protobuf message.
+//
+// Nested messages are added to definitions by their full names (e.g. "my.pkg.Item"),
+// well-known types (e.g. google.protobuf.Timestamp) are reflected as their protojson representations.
+func (r *Reflector) Reflect(m proto.Message) (jsonschema.Schema, error) {
+	md := m.ProtoReflect().Descriptor()
+
+	rc := reflectContext{
+		root:        md.FullName(),
+		prefix:      r.DefinitionsPrefix,
+		definitions: map[string]jsonschema.SchemaOrBool{},
+	}
+
+	if rc.prefix == "" {
+		rc.prefix = "#/definitions/"
+	}
+
+	if s, ok := wellKnownType(md.FullName()); ok {
+		return s, nil
+	}
+
+	s, err := r.message(&rc, md)
+	if err != nil {
+		return s, err
+	}
+
+	if len(rc.definitions) > 0 {
+		s.Definitions = rc.definitions
+	}
+
+	return s, nil
+}
+
+func (r *Reflector) message(rc *reflectContext, md protoreflect.MessageDescriptor) (jsonschema.Schema, error) {
+	s := jsonschema.Schema{}
+	s.AddType(jsonschema.Object)
+
+	fields := md.Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		ps, err := r.field(rc, fd)
+		if err != nil {
+			return s, fmt.Errorf("%s: %w", fd.FullName(), err)
+		}
+
+		name := r.fieldName(fd)
+
+		s.WithPropertiesItem(name, ps.ToSchemaOrBool())
+
+		if fd.Cardinality() == protoreflect.Required {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	var groups [][]jsonschema.SchemaOrBool
+
+	oneofs := md.Oneofs()
+
+	for i := 0; i < oneofs.Len(); i++ {
+		if od := oneofs.Get(i); !od.IsSynthetic() {
+			groups = append(groups, r.oneOf(od))
+		}
+	}
+
+	if len(groups) == 1 {
+		s.OneOf = groups[0]
+	} else {
+		for _, g := range groups {
+			s.AllOf = append(s.AllOf, (&jsonschema.Schema{OneOf: g}).ToSchemaOrBool())
+		}
+	}
+
+	return s, nil
+}
+
+// oneOf allows at most one field of oneof to be set: either exactly one field is present or none of them.
+func (r *Reflector) oneOf(od protoreflect.OneofDescriptor) []jsonschema.SchemaOrBool {
+	var (
+		none  jsonschema.Schema
+		anyOf []jsonschema.SchemaOrBool
+	)
+
+	fields := od.Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		set := jsonschema.Schema{}
+		set.WithRequired(r.fieldName(fields.Get(i)))
+
+		anyOf = append(anyOf, set.ToSchemaOrBool())
+	}
+
+	none.WithNot((&jsonschema.Schema{AnyOf: anyOf}).ToSchemaOrBool())
+
+	return append(append([]jsonschema.SchemaOrBool{}, anyOf...), none.ToSchemaOrBool())
+}
+
+func (r *Reflector) fieldName(fd protoreflect.FieldDescriptor) string {
+	if r.UseProtoNames {
+		return string(fd.Name())
+	}
+
+	return fd.JSONName()
+}
+
+func (r *Reflector) field(rc *reflectContext, fd protoreflect.FieldDescriptor) (jsonschema.Schema, error) {
+	switch {
+	case fd.IsMap():
+		vs, err := r.value(rc, fd.MapValue())
+		if err != nil {
+			return vs, err
+		}
+
+		s := jsonschema.Schema{}
+		s.AddType(jsonschema.Object)
+		s.WithAdditionalProperties(vs.ToSchemaOrBool())
+
+		return s, nil
+	case fd.IsList():
+		is, err := r.value(rc, fd)
+		if err != nil {
+			return is, err
+		}
+
+		s := jsonschema.Schema{}
+		s.AddType(jsonschema.Array)
+		s.WithItems(*(&jsonschema.Items{}).WithSchemaOrBool(is.ToSchemaOrBool()))
+
+		return s, nil
+	default:
+		return r.value(rc, fd)
+	}
+}
+
+// value reflects singular value of a field, for repeated fields it is an item.
+func (r *Reflector) value(rc *reflectContext, fd protoreflect.FieldDescriptor) (jsonschema.Schema, error) {
+	s := jsonschema.Schema{}
+
+	//nolint:exhaustive // Other kinds are messages and groups.
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		s.AddType(jsonschema.Boolean)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		s.AddType(jsonschema.Integer)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// 64-bit integers are represented as strings by protojson.
+		s.AddType(jsonschema.String)
+		s.WithPattern(`^-?[0-9]+$`)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		s.AddType(jsonschema.String)
+		s.WithPattern(`^[0-9]+$`)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		s.AddType(jsonschema.Number)
+	case protoreflect.StringKind:
+		s.AddType(jsonschema.String)
+	case protoreflect.BytesKind:
+		s.AddType(jsonschema.String)
+		s.WithFormat("base64")
+	case protoreflect.EnumKind:
+		r.enum(&s, fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return r.messageRef(rc, fd.Message())
+	default:
+		return s, fmt.Errorf("unsupported kind %s", fd.Kind())
+	}
+
+	return s, nil
+}
+
+func (r *Reflector) enum(s *jsonschema.Schema, ed protoreflect.EnumDescriptor) {
+	if ed.FullName() == "google.protobuf.NullValue" {
+		s.AddType(jsonschema.Null)
+
+		return
+	}
+
+	values := ed.Values()
+
+	for i := 0; i < values.Len(); i++ {
+		v := values.Get(i)
+
+		if r.UseEnumNumbers {
+			s.Enum = append(s.Enum, int(v.Number()))
+		} else {
+			s.Enum = append(s.Enum, string(v.Name()))
+		}
+	}
+
+	if r.UseEnumNumbers {
+		s.AddType(jsonschema.Integer)
+	} else {
+		s.AddType(jsonschema.String)
+	}
+}
+
+// messageRef returns reference to message definition, definition is reflected if it is missing.
+func (r *Reflector) messageRef(rc *reflectContext, md protoreflect.MessageDescriptor) (jsonschema.Schema, error) {
+	if s, ok := wellKnownType(md.FullName()); ok {
+		return s, nil
+	}
+
+	if md.FullName() == rc.root {
+		return *(&jsonschema.Schema{}).WithRef("#"), nil
+	}
+
+	name := string(md.FullName())
+	ref := *(&jsonschema.Schema{}).WithRef(rc.prefix + strings.ReplaceAll(name, "~", "~0"))
+
+	if _, ok := rc.definitions[name]; ok {
+		return ref, nil
+	}
+
+	// Placeholder prevents infinite recursion of self-referencing messages.
+	rc.definitions[name] = jsonschema.SchemaOrBool{}
+
+	s, err := r.message(rc, md)
+	if err != nil {
+		return s, err
+	}
+
+	rc.definitions[name] = s.ToSchemaOrBool()
+
+	return ref, nil
+}
+
+// wellKnownType returns schema of protojson representation of a well-known type.
+func wellKnownType(name protoreflect.FullName) (jsonschema.Schema, bool) {
+	s := jsonschema.Schema{}
+
+	switch name {
+	case "google.protobuf.Timestamp":
+		s.AddType(jsonschema.String)
+		s.WithFormat("date-time")
+	case "google.protobuf.Duration":
+		s.AddType(jsonschema.String)
+		s.WithPattern(`^-?[0-9]+(\.[0-9]{1,9})?s$`)
+	case "google.protobuf.FieldMask":
+		s.AddType(jsonschema.String)
+	case "google.protobuf.Empty":
+		s.AddType(jsonschema.Object)
+	case "google.protobuf.Any":
+		s.AddType(jsonschema.Object)
+		s.WithPropertiesItem("@type", jsonschema.String.ToSchemaOrBool())
+		s.WithRequired("@type")
+	case "google.protobuf.Struct":
+		s.AddType(jsonschema.Object)
+	case "google.protobuf.ListValue":
+		s.AddType(jsonschema.Array)
+	case "google.protobuf.Value":
+		// Any JSON value.
+	case "google.protobuf.StringValue":
+		s.Type = &jsonschema.Type{SliceOfSimpleTypeValues: []jsonschema.SimpleType{jsonschema.String, jsonschema.Null}}
+	case "google.protobuf.BytesValue":
+		s.Type = &jsonschema.Type{SliceOfSimpleTypeValues: []jsonschema.SimpleType{jsonschema.String, jsonschema.Null}}
+		s.WithFormat("base64")
+	case "google.protobuf.BoolValue":
+		s.Type = &jsonschema.Type{SliceOfSimpleTypeValues: []jsonschema.SimpleType{jsonschema.Boolean, jsonschema.Null}}
+	case "google.protobuf.Int32Value", "google.protobuf.UInt32Value":
+		s.Type = &jsonschema.Type{SliceOfSimpleTypeValues: []jsonschema.SimpleType{jsonschema.Integer, jsonschema.Null}}
+	case "google.protobuf.Int64Value", "google.protobuf.UInt64Value":
+		s.Type = &jsonschema.Type{SliceOfSimpleTypeValues: []jsonschema.SimpleType{jsonschema.String, jsonschema.Null}}
+		s.WithPattern(`^-?[0-9]+$`)
+	case "google.protobuf.FloatValue", "google.protobuf.DoubleValue":
+		s.Type = &jsonschema.Type{SliceOfSimpleTypeValues: []jsonschema.SimpleType{jsonschema.Number, jsonschema.Null}}
+	default:
+		return s, false
+	}
+
+	return s, true
+}
@@ -0,0 +1,144 @@
+package protoschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go/protoschema"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type,
+	label descriptorpb.FieldDescriptorProto_Label, typeName string,
+) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Type:   typ.Enum(),
+		Label:  label.Enum(),
+	}
+
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+
+	return f
+}
+
+func orderMessage(t *testing.T) proto.Message {
+	t.Helper()
+
+	const (
+		optional = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		repeated = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	)
+
+	card := field("card_number", 6, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, "")
+	card.OneofIndex = proto.Int32(0)
+
+	cash := field("cash", 7, descriptorpb.FieldDescriptorProto_TYPE_BOOL, optional, "")
+	cash.OneofIndex = proto.Int32(0)
+
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("shop/order.proto"),
+		Package:    proto.String("shop"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto", "google/protobuf/wrappers.proto"},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("STATUS_UNKNOWN"), Number: proto.Int32(0)},
+				{Name: proto.String("STATUS_PAID"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Item"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("sku", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+					field("quantity", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64, optional, ""),
+					field("children", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, repeated, ".shop.Item"),
+				},
+			},
+			{
+				Name: proto.String("Order"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("items", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, repeated, ".shop.Item"),
+					field("status", 2, descriptorpb.FieldDescriptorProto_TYPE_ENUM, optional, ".shop.Status"),
+					field("created_at", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional,
+						".google.protobuf.Timestamp"),
+					field("note", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional,
+						".google.protobuf.StringValue"),
+					field("labels", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, repeated,
+						".shop.Order.LabelsEntry"),
+					card, cash,
+					field("parent", 8, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional, ".shop.Order"),
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("payment")}},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name: proto.String("LabelsEntry"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+						field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE, optional, ""),
+					},
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				}},
+			},
+		},
+	}
+
+	f, err := protodesc.NewFile(fd, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+
+	return dynamicpb.NewMessage(f.Messages().ByName("Order"))
+}
+
+func TestReflector_Reflect(t *testing.T) {
+	m := orderMessage(t)
+	r := protoschema.Reflector{}
+
+	s, err := r.Reflect(m)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"shop.Item":{
+		  "properties":{
+			"children":{"items":{"$ref":"#/definitions/shop.Item"},"type":"array"},
+			"quantity":{"pattern":"^-?[0-9]+$","type":"string"},
+			"sku":{"type":"string"}
+		  },
+		  "type":"object"
+		}
+	  },
+	  "oneOf":[
+		{"required":["cardNumber"]},{"required":["cash"]},
+		{"not":{"anyOf":[{"required":["cardNumber"]},{"required":["cash"]}]}}
+	  ],
+	  "properties":{
+		"cardNumber":{"type":"string"},"cash":{"type":"boolean"},
+		"createdAt":{"type":"string","format":"date-time"},
+		"items":{"items":{"$ref":"#/definitions/shop.Item"},"type":"array"},
+		"labels":{"additionalProperties":{"type":"number"},"type":"object"},
+		"note":{"type":["string","null"]},"parent":{"$ref":"#"},
+		"status":{"enum":["STATUS_UNKNOWN","STATUS_PAID"],"type":"string"}
+	  },
+	  "type":"object"
+	}`, s)
+
+	r.UseProtoNames = true
+	r.UseEnumNumbers = true
+
+	s, err = r.Reflect(m)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{"enum":[0,1],"type":"integer"}`, s.Properties["status"])
+	assertjson.EqMarshal(t, `{"type":"string","format":"date-time"}`, s.Properties["created_at"])
+}
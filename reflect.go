@@ -2,31 +2,55 @@ package jsonschema
 
 import (
 	"context"
+	"database/sql"
 	"encoding"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"math/big"
+	"mime/multipart"
+	"net"
+	"net/netip"
+	"net/url"
 	"path"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/swaggest/refl"
 )
 
 var (
-	typeOfJSONRawMsg      = reflect.TypeOf(json.RawMessage{})
-	typeOfByteSlice       = reflect.TypeOf([]byte{})
-	typeOfTime            = reflect.TypeOf(time.Time{})
-	typeOfDate            = reflect.TypeOf(Date{})
-	typeOfTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
-	typeOfTextMarshaler   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
-	typeOfJSONMarshaler   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
-	typeOfEmptyInterface  = reflect.TypeOf((*interface{})(nil)).Elem()
-	typeOfSchemaInliner   = reflect.TypeOf((*SchemaInliner)(nil)).Elem()
-	typeOfEmbedReferencer = reflect.TypeOf((*EmbedReferencer)(nil)).Elem()
+	typeOfJSONRawMsg          = reflect.TypeOf(json.RawMessage{})
+	typeOfByteSlice           = reflect.TypeOf([]byte{})
+	typeOfTime                = reflect.TypeOf(time.Time{})
+	typeOfDuration            = reflect.TypeOf(time.Duration(0))
+	typeOfSQLNullTime         = reflect.TypeOf(sql.NullTime{})
+	typeOfNetIP               = reflect.TypeOf(net.IP{})
+	typeOfNetipAddr           = reflect.TypeOf(netip.Addr{})
+	typeOfURL                 = reflect.TypeOf(url.URL{})
+	typeOfJSONNumber          = reflect.TypeOf(json.Number(""))
+	typeOfBigInt              = reflect.TypeOf(big.Int{})
+	typeOfBigFloat            = reflect.TypeOf(big.Float{})
+	typeOfBigRat              = reflect.TypeOf(big.Rat{})
+	typeOfDate                = reflect.TypeOf(Date{})
+	typeOfFileHeader          = reflect.TypeOf(multipart.FileHeader{})
+	typeOfMultipartFile       = reflect.TypeOf((*multipart.File)(nil)).Elem()
+	typeOfReader              = reflect.TypeOf((*io.Reader)(nil)).Elem()
+	typeOfTextUnmarshaler     = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	typeOfTextMarshaler       = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	typeOfJSONMarshaler       = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	typeOfEmptyInterface      = reflect.TypeOf((*interface{})(nil)).Elem()
+	typeOfSchemaInliner       = reflect.TypeOf((*SchemaInliner)(nil)).Elem()
+	typeOfEmbedReferencer     = reflect.TypeOf((*EmbedReferencer)(nil)).Elem()
+	typeOfIgnoreTextMarshaler = reflect.TypeOf((*IgnoreTextMarshaler)(nil)).Elem()
+	typeOfTupleStruct         = reflect.TypeOf((*TupleStruct)(nil)).Elem()
 )
 
 const (
@@ -45,6 +69,13 @@ type IgnoreTypeName interface {
 	IgnoreTypeName()
 }
 
+// IgnoreTextMarshaler is a marker interface to disable string schema of encoding.TextMarshaler implementation.
+//
+// Such types are reflected by their kind (e.g. struct fields) as if they did not implement TextMarshaler.
+type IgnoreTextMarshaler interface {
+	IgnoreTextMarshaler()
+}
+
 // SchemaInliner is a marker interface to inline schema without creating a definition.
 type SchemaInliner interface {
 	InlineJSONSchema()
@@ -55,6 +86,14 @@ type EmbedReferencer interface {
 	ReferEmbedded()
 }
 
+// TupleStruct is a marker interface to reflect struct as a fixed size array (tuple) of its exported field values.
+//
+// Such struct is expected to be marshaled to JSON as an array, e.g. with custom json.Marshaler.
+// Field tags with schema keywords (e.g. `minimum:"0"`) are applied to tuple items.
+type TupleStruct interface {
+	TupleStruct()
+}
+
 // IgnoreTypeName instructs reflector to keep original type name during mapping.
 func (s Schema) IgnoreTypeName() {}
 
@@ -91,16 +130,43 @@ func (r Ref) Schema() Schema {
 
 // Reflector creates JSON Schemas from Go values.
 type Reflector struct {
-	DefaultOptions   []func(*ReflectContext)
-	typesMap         map[reflect.Type]interface{}
-	inlineDefinition map[refl.TypeString]bool
-	defNameTypes     map[string]reflect.Type
+	DefaultOptions []func(*ReflectContext)
+
+	// CacheDefinitions enables reuse of definitions between Reflect calls.
+	//
+	// Processed definition schemas of types reflected from zero values are cached per type and
+	// are used in next Reflect calls instead of reflecting the type again, this is useful when many
+	// schemas share models. Cache is reset when Reflector is configured (e.g. with AddTypeMapping).
+	//
+	// Cached definitions are only used with the same reflect options (e.g. DefinitionsPrefix). Options
+	// with functions or mappings (e.g. InterceptSchema, InterceptProp, InterceptDefName, TagMapping) can not be
+	// compared, so Reflect calls with such options do not use or populate the cache.
+	CacheDefinitions bool
+
+	typesMap          map[reflect.Type]interface{}
+	inlineDefinition  map[refl.TypeString]bool
+	defNameTypes      map[string]reflect.Type
+	formats           map[string]bool
+	interfaceImpls    map[reflect.Type][]interface{}
+	enums             map[reflect.Type]enum
+	preparers         map[reflect.Type][]func(schema *Schema) error
+	fieldOverrides    map[reflect.Type]map[string]Schema
+	schemaFiles       map[reflect.Type]Schema
+	skipTextMarshaler map[reflect.Type]bool
+	skipWellKnown     map[reflect.Type]bool
+	wellKnown         map[reflect.Type]Schema
+	defCache          map[refl.TypeString]cachedDefinition
+
+	// mu guards state that is updated during reflection (definition names, schema files and cache).
+	mu sync.Mutex
 }
 
 // AddTypeMapping creates substitution link between types of src and dst when reflecting JSON Schema.
 //
 // A configured Schema instance can also be used as dst.
 func (r *Reflector) AddTypeMapping(src, dst interface{}) {
+	r.defCache = nil
+
 	if r.typesMap == nil {
 		r.typesMap = map[reflect.Type]interface{}{}
 	}
@@ -112,6 +178,8 @@ func (r *Reflector) AddTypeMapping(src, dst interface{}) {
 //
 // Inlined schema is used instead of a reference to a shared definition.
 func (r *Reflector) InlineDefinition(sample interface{}) {
+	r.defCache = nil
+
 	if r.inlineDefinition == nil {
 		r.inlineDefinition = map[refl.TypeString]bool{}
 	}
@@ -119,10 +187,130 @@ func (r *Reflector) InlineDefinition(sample interface{}) {
 	r.inlineDefinition[refl.GoType(refl.DeepIndirect(reflect.TypeOf(sample)))] = true
 }
 
+// AddInterfaceImpls registers implementations of an interface type.
+//
+// Interface type is defined by a pointer to it, e.g. new(Shape).
+// Values of interface type are reflected as "oneOf" of implementations schemas.
+func (r *Reflector) AddInterfaceImpls(iface interface{}, impls ...interface{}) {
+	r.defCache = nil
+
+	if r.interfaceImpls == nil {
+		r.interfaceImpls = map[reflect.Type][]interface{}{}
+	}
+
+	t := refl.DeepIndirect(reflect.TypeOf(iface))
+
+	r.interfaceImpls[t] = append(r.interfaceImpls[t], impls...)
+}
+
+// AddEnum registers enumerated acceptable values for a type of given sample.
+//
+// This is useful for types that can not implement Enum, e.g. third-party types.
+func (r *Reflector) AddEnum(sample interface{}, values ...interface{}) {
+	r.AddNamedEnum(sample, values, nil)
+}
+
+// AddNamedEnum registers enumerated acceptable values with according names for a type of given sample.
+func (r *Reflector) AddNamedEnum(sample interface{}, values []interface{}, names []string) {
+	r.defCache = nil
+
+	if r.enums == nil {
+		r.enums = map[reflect.Type]enum{}
+	}
+
+	r.enums[refl.DeepIndirect(reflect.TypeOf(sample))] = enum{items: values, names: names}
+}
+
+// AddPreparer registers a function to alter reflected JSON Schema of a type of given sample.
+//
+// This is useful for types that can not implement Preparer, e.g. third-party types.
+// Registered functions are invoked after Preparer of the type.
+func (r *Reflector) AddPreparer(sample interface{}, prepare func(schema *Schema) error) {
+	r.defCache = nil
+
+	if r.preparers == nil {
+		r.preparers = map[reflect.Type][]func(schema *Schema) error{}
+	}
+
+	t := refl.DeepIndirect(reflect.TypeOf(sample))
+
+	r.preparers[t] = append(r.preparers[t], prepare)
+}
+
+// AddFieldOverride registers schema override for a struct field of a type of given sample.
+//
+// Non-empty values of override are merged into reflected property schema of the field.
+// This is useful to annotate third-party or generated structures that can not have field tags edited.
+func (r *Reflector) AddFieldOverride(sample interface{}, fieldName string, override Schema) {
+	r.defCache = nil
+
+	if r.fieldOverrides == nil {
+		r.fieldOverrides = map[reflect.Type]map[string]Schema{}
+	}
+
+	t := refl.DeepIndirect(reflect.TypeOf(sample))
+
+	if r.fieldOverrides[t] == nil {
+		r.fieldOverrides[t] = map[string]Schema{}
+	}
+
+	r.fieldOverrides[t][fieldName] = override
+}
+
+// SkipTextMarshaler disables string schema of encoding.TextMarshaler implementation for types of given samples.
+//
+// This is useful for types that can not implement IgnoreTextMarshaler, e.g. third-party types.
+func (r *Reflector) SkipTextMarshaler(samples ...interface{}) {
+	r.defCache = nil
+
+	if r.skipTextMarshaler == nil {
+		r.skipTextMarshaler = map[reflect.Type]bool{}
+	}
+
+	for _, sample := range samples {
+		r.skipTextMarshaler[refl.DeepIndirect(reflect.TypeOf(sample))] = true
+	}
+}
+
+// SkipWellKnownTypes disables built-in schemas of well-known types (e.g. time.Time, uuid.UUID, net.IP, url.URL)
+// for types of given samples, such types are reflected as regular Go types.
+func (r *Reflector) SkipWellKnownTypes(samples ...interface{}) {
+	r.defCache = nil
+
+	if r.skipWellKnown == nil {
+		r.skipWellKnown = map[reflect.Type]bool{}
+	}
+
+	for _, sample := range samples {
+		r.skipWellKnown[refl.DeepIndirect(reflect.TypeOf(sample))] = true
+	}
+}
+
+// AddWellKnownType registers schema of a well-known type of given sample.
+//
+// Registered schema replaces built-in schema of the type (e.g. to remove canned UUID example or to describe []byte
+// with contentEncoding), it can also be used for other types that should have a fixed schema.
+// Nullability of pointers is still applied.
+func (r *Reflector) AddWellKnownType(sample interface{}, schema Schema) {
+	r.defCache = nil
+
+	if r.wellKnown == nil {
+		r.wellKnown = map[reflect.Type]Schema{}
+	}
+
+	r.wellKnown[refl.DeepIndirect(reflect.TypeOf(sample))] = schema
+}
+
+func (r *Reflector) ignoresTextMarshaler(t reflect.Type) bool {
+	return r.skipTextMarshaler[t] || t.Implements(typeOfIgnoreTextMarshaler) || reflect.PtrTo(t).Implements(typeOfIgnoreTextMarshaler)
+}
+
 // InterceptDefName allows modifying reflected definition names.
 //
 // Deprecated: add jsonschema.InterceptDefName to DefaultOptions.
 func (r *Reflector) InterceptDefName(f func(t reflect.Type, defaultDefName string) string) {
+	r.defCache = nil
+
 	r.DefaultOptions = append(r.DefaultOptions, InterceptDefName(f))
 }
 
@@ -130,8 +318,47 @@ func checkSchemaSetup(params InterceptSchemaParams) (bool, error) {
 	v := params.Value
 	s := params.Schema
 
-	reflectEnum(s, "", v.Interface())
+	if err := reflectEnum(s, "", v.Interface()); err != nil {
+		return true, err
+	}
+
+	exposed, err := checkSchemaExposer(v, s)
+	if err != nil {
+		return true, err
+	}
+
+	// Const, deprecation and extras are applied on top of exposed schema.
+	if ce, ok := safeInterface(v).(ConstExposer); ok {
+		s.WithConst(ce.JSONSchemaConst())
+	} else if ce, ok := ptrTo(v).(ConstExposer); ok {
+		s.WithConst(ce.JSONSchemaConst())
+	}
+
+	var d Deprecated
+	if de, ok := safeInterface(v).(Deprecated); ok {
+		d = de
+	} else if de, ok := ptrTo(v).(Deprecated); ok {
+		d = de
+	}
+
+	if d != nil && d.JSONSchemaDeprecated() {
+		s.WithExtraPropertiesItem("deprecated", true)
+	}
+
+	var extras map[string]interface{}
+	if ee, ok := safeInterface(v).(ExtrasExposer); ok {
+		extras = ee.JSONSchemaExtras()
+	} else if ee, ok := ptrTo(v).(ExtrasExposer); ok {
+		extras = ee.JSONSchemaExtras()
+	}
+
+	s.WithExtraPropertiesItems(extras)
+
+	return exposed, nil
+}
 
+// checkSchemaExposer replaces schema with result of Exposer or RawExposer.
+func checkSchemaExposer(v reflect.Value, s *Schema) (bool, error) {
 	var e Exposer
 
 	if exposer, ok := safeInterface(v).(Exposer); ok {
@@ -216,10 +443,17 @@ func checkSchemaSetup(params InterceptSchemaParams) (bool, error) {
 //   - `exclusiveMaximum`, https://json-schema.org/draft-04/json-schema-validation.html#rfc.section.5.1.2
 //   - `exclusiveMinimum`, https://json-schema.org/draft-04/json-schema-validation.html#rfc.section.5.1.3
 //   - `uniqueItems`, https://json-schema.org/draft-04/json-schema-validation.html#rfc.section.5.3.4
-//   - `enum`, tag value must be a JSON or comma-separated list of strings,
+//   - `enum`, tag value must be a JSON or comma-separated list of values (typed by property type),
 //     https://json-schema.org/draft-04/json-schema-validation.html#rfc.section.5.5.1
 //   - `required`, boolean, marks property as required
-//   - `nullable`, boolean, overrides nullability of a property
+//   - `deprecated`, boolean, marks property as deprecated
+//   - `deprecatedMsg`, deprecation reason, requires `deprecated:"true"`,
+//     emitted as `x-deprecated-reason` (see DeprecatedReasonProperty)
+//   - `nullable`, boolean, overrides nullability of a property, reference is enveloped in `anyOf` with `null`
+//   - `inline`, boolean, inlines schema of property type instead of referencing shared definition,
+//     for unnamed slice, array or map it applies to element type
+//   - `defName`, overrides definition name of property type for this property,
+//     for unnamed slice, array or map it applies to element type
 //
 // Unnamed fields can be used to configure parent schema:
 //
@@ -229,6 +463,9 @@ func checkSchemaSetup(params InterceptSchemaParams) (bool, error) {
 //	   _             struct{} `additionalProperties:"false" description:"MyObj is my object."`
 //	}
 //
+// Unnamed field tag `required` accepts comma-separated list of property names to add to parent `required`,
+// e.g. `_ struct{} required:"boundedNumber,specialString"`.
+//
 // In case of a structure with multiple name tags, you can enable filtering of unnamed fields with
 // ReflectContext.UnnamedFieldWithTag option and add matching name tags to structure (e.g. query:"_").
 //
@@ -240,14 +477,15 @@ func checkSchemaSetup(params InterceptSchemaParams) (bool, error) {
 //	}
 //
 // Additionally there are structure can implement any of special interfaces for fine-grained Schema control:
-// RawExposer, Exposer, Preparer.
+// RawExposer, Exposer, ReflectingExposer, SchemaFileExposer, Preparer, PropertyPreparer.
 //
 // These interfaces allow exposing particular schema keywords:
-// Titled, Described, Enum, NamedEnum.
+// Titled, Described, Enum, NamedEnum, DescribedEnum, ConstExposer, Deprecated, RequiredExposer, ExtrasExposer.
 //
 // Available options:
 //
 //		CollectDefinitions
+//		CollectDefinitionsTo
 //		DefinitionsPrefix
 //		PropertyNameTag
 //		InterceptNullability
@@ -259,9 +497,32 @@ func checkSchemaSetup(params InterceptSchemaParams) (bool, error) {
 //		RootRef
 //		StripDefinitionNamePrefix
 //		PropertyNameMapping
+//		TagMapping
+//		ExclusiveBoundsDraft04
+//		StrictTags
+//		BigNumbers
+//		DeprecatedReasonProperty
+//		ValidatorTags
+//		InferJSONMarshalers
+//		VerifyExamples
+//		SelfValidate
+//		StrictKeywords
+//		Draft201909
+//		Draft202012
+//		ForbidUnevaluatedProperties
+//		OpenAPI30
+//		Swagger20
+//		CollectWarnings
 //		ProcessWithoutTags
 //		SkipEmbeddedMapsSlices
 //		SkipUnsupportedProperties
+//		SkipElementSamples
+//		DurationAsString
+//		BigTypesAsStrings
+//		DecimalAsNumber
+//		JSONNumberAs
+//		ReaderAsBinary
+//		InferNullableWrappers
 //
 // Fields from embedded structures are processed as if they were defined in the root structure.
 // Alternatively, if embedded structure has a field tag `refer:"true"` or implements EmbedReferencer,
@@ -274,7 +535,7 @@ func (r *Reflector) Reflect(i interface{}, options ...func(rc *ReflectContext))
 	rc.Path = []string{"#"}
 	rc.typeCycles = make(map[refl.TypeString]*Schema)
 
-	InterceptSchema(checkSchemaSetup)(&rc)
+	rc.interceptSchema = checkSchemaSetup
 
 	for _, option := range r.DefaultOptions {
 		option(&rc)
@@ -287,21 +548,59 @@ func (r *Reflector) Reflect(i interface{}, options ...func(rc *ReflectContext))
 	rc.deprecatedFallback()
 
 	schema, err := r.reflect(i, &rc, false, nil)
+	if err == nil && len(rc.valueChecks) > 0 {
+		err = rc.verifyValues(&schema)
+	}
+
+	if err == nil {
+		r.cacheDefinitions(&rc)
+	}
+
+	if err == nil && rc.CollectDefinitions == nil && rc.DefinitionsSink == nil {
+		rc.pruneUnreachableDefinitions(&schema)
+	}
+
 	if err == nil && len(rc.definitions) > 0 {
 		schema.Definitions = make(map[string]SchemaOrBool, len(rc.definitions))
 
-		for typeString, def := range rc.definitions {
-			def := def
+		for _, typeString := range rc.sortedDefinitions() {
+			def := rc.definitions[typeString]
 			ref := rc.definitionRefs[typeString]
 
+			if rc.CollectDefinitions == nil && rc.DefinitionsSink == nil {
+				schema.Definitions[ref.Name] = def.ToSchemaOrBool()
+
+				continue
+			}
+
+			rc.convertDialect(ref.Path+ref.Name, def)
+			rc.strictKeywords(def)
+
+			if rc.SelfValidate && err == nil {
+				err = rc.selfValidate(ref.Path+ref.Name, *def)
+			}
+
 			if rc.CollectDefinitions != nil {
 				rc.CollectDefinitions(ref.Name, *def)
-			} else {
-				schema.Definitions[ref.Name] = def.ToSchemaOrBool()
+			}
+
+			if rc.DefinitionsSink != nil && err == nil {
+				if err = rc.DefinitionsSink.Put(ref.Name, *def); err != nil {
+					err = fmt.Errorf("put definition %s: %w", ref.Name, err)
+				}
 			}
 		}
 	}
 
+	if err == nil {
+		rc.convertDialect("#", &schema)
+		rc.strictKeywords(&schema)
+	}
+
+	if err == nil && rc.SelfValidate {
+		err = rc.selfValidate("#", schema)
+	}
+
 	return schema, err
 }
 
@@ -415,6 +714,17 @@ func (r *Reflector) reflect(i interface{}, rc *ReflectContext, keepType bool, pa
 		s = st.structPtr()
 	}
 
+	inline := rc.inlineNext
+	rc.inlineNext = false
+
+	customDefName := rc.defNameNext
+	rc.defNameNext = ""
+
+	// Nested virtual struct without DefName is reflected inline.
+	if s != nil && s.DefName == "" && len(rc.Path) > 1 {
+		inline = true
+	}
+
 	defer func() {
 		rc.Path = rc.Path[:len(rc.Path)-1]
 
@@ -426,6 +736,14 @@ func (r *Reflector) reflect(i interface{}, rc *ReflectContext, keepType bool, pa
 			return
 		}
 
+		if rc.ExclusiveBoundsDraft04 {
+			exclusiveBoundsDraft04(&schema)
+		}
+
+		if inline {
+			return
+		}
+
 		schema = r.reflectDefer(defName, typeString, rc, schema, keepType)
 	}()
 
@@ -450,8 +768,9 @@ func (r *Reflector) reflect(i interface{}, rc *ReflectContext, keepType bool, pa
 
 	typeString = refl.GoType(t)
 	defName = r.defName(rc, t)
+	origType := t
 
-	if s != nil {
+	if s != nil && !inline {
 		defName, typeString = s.names()
 	}
 
@@ -465,10 +784,60 @@ func (r *Reflector) reflect(i interface{}, rc *ReflectContext, keepType bool, pa
 		}
 	}
 
+	if vt, nullable, ok := nullableWrapper(rc, t); ok && s == nil {
+		if nullable || vt.Kind() == reflect.Ptr {
+			schema.AddType(Null)
+		}
+
+		t = refl.DeepIndirect(vt)
+		v = reflect.New(t)
+		typeString = refl.GoType(t)
+		defName = r.defName(rc, t)
+	}
+
+	// Field tags of unnamed slice, array or map apply to its element type.
+	var (
+		elemInline  bool
+		elemDefName string
+	)
+
+	if t.Name() == "" && t != typeOfJSONRawMsg {
+		switch t.Kind() { //nolint:exhaustive // Only containers are relevant.
+		case reflect.Slice, reflect.Array, reflect.Map:
+			elemInline, inline = inline, false
+			elemDefName, customDefName = customDefName, ""
+		}
+	}
+
+	if customDefName != "" {
+		defName = r.customDefName(t, customDefName)
+		typeString = refl.TypeString(string(typeString) + "@" + defName)
+	}
+
+	// Recursive type is referenced instead of being inlined again.
+	if inline && defName != "" && s == nil {
+		if rc.inlining[typeString] {
+			inline = false
+		} else {
+			if rc.inlining == nil {
+				rc.inlining = map[refl.TypeString]bool{}
+			}
+
+			rc.inlining[typeString] = true
+
+			defer delete(rc.inlining, typeString)
+		}
+	}
+
 	if len(rc.Path) == 1 {
 		rc.rootDefName = defName
 	}
 
+	if defName != "" && !inline && !rc.InlineRefs && !r.inlineDefinition[typeString] &&
+		r.cachedDefinition(rc, typeString, defName, v, &schema) {
+		return schema, nil
+	}
+
 	// Shortcut on embedded map or slice.
 	if !rc.SkipEmbeddedMapsSlices {
 		if et := refl.FindEmbeddedSliceOrMap(i); et != nil {
@@ -489,21 +858,39 @@ func (r *Reflector) reflect(i interface{}, rc *ReflectContext, keepType bool, pa
 		}
 	}
 
-	if r.isWellKnownType(t, sp) {
-		return schema, nil
+	if ok, err := r.checkReflectingExposer(v, rc, sp); ok || err != nil {
+		return schema, err
+	}
+
+	if ok, err := r.checkSchemaFileExposer(v, sp); ok || err != nil {
+		return schema, err
+	}
+
+	if e, ok := r.enums[origType]; ok {
+		e.apply(sp)
 	}
 
-	isTextMarshaler := checkTextMarshaler(t, &schema)
+	if r.isWellKnownType(rc, t, sp) {
+		return schema, r.applyPreparers(origType, sp)
+	}
+
+	isTextMarshaler := !r.ignoresTextMarshaler(t) && checkTextMarshaler(t, &schema)
 
-	if ref, ok := rc.definitionRefs[typeString]; ok && defName != "" {
+	if ref, ok := rc.definitionRefs[typeString]; ok && defName != "" && !inline {
 		return ref.Schema(), nil
 	}
 
-	if rc.typeCycles[typeString] != nil && !rc.InlineRefs {
+	if rc.InferJSONMarshalers {
+		if ok, err := checkJSONMarshaler(t, v, sp); ok || err != nil {
+			return schema, err
+		}
+	}
+
+	if rc.typeCycles[typeString] != nil && !rc.InlineRefs && !inline {
 		return *rc.typeCycles[typeString], nil
 	}
 
-	if t.PkgPath() != "" && len(rc.Path) > 1 && defName != "" && !r.inlineDefinition[typeString] {
+	if t.PkgPath() != "" && len(rc.Path) > 1 && defName != "" && !r.inlineDefinition[typeString] && !inline {
 		rc.typeCycles[typeString] = sp
 	}
 
@@ -514,11 +901,20 @@ func (r *Reflector) reflect(i interface{}, rc *ReflectContext, keepType bool, pa
 	}
 
 	if !isTextMarshaler {
+		rc.inlineNext = elemInline
+		rc.defNameNext = elemDefName
+
 		if err = r.kindSwitch(t, v, sp, rc); err != nil {
 			return schema, err
 		}
 	}
 
+	if re, ok := safeInterface(v).(RequiredExposer); ok {
+		addRequired(sp, re.JSONSchemaRequired()...)
+	} else if re, ok := ptrTo(v).(RequiredExposer); ok {
+		addRequired(sp, re.JSONSchemaRequired()...)
+	}
+
 	if rc.interceptSchema != nil {
 		if ret, err := rc.interceptSchema(InterceptSchemaParams{
 			Context:   rc,
@@ -531,16 +927,115 @@ func (r *Reflector) reflect(i interface{}, rc *ReflectContext, keepType bool, pa
 	}
 
 	if preparer, ok := safeInterface(v).(Preparer); ok {
-		err := preparer.PrepareJSONSchema(sp)
-
-		return schema, err
+		if err := preparer.PrepareJSONSchema(sp); err != nil {
+			return schema, err
+		}
 	} else if preparer, ok := ptrTo(v).(Preparer); ok {
-		err := preparer.PrepareJSONSchema(sp)
+		if err := preparer.PrepareJSONSchema(sp); err != nil {
+			return schema, err
+		}
+	}
 
-		return schema, err
+	return schema, r.applyPreparers(origType, sp)
+}
+
+func (r *Reflector) applyPreparers(t reflect.Type, schema *Schema) error {
+	for _, prepare := range r.preparers[t] {
+		if err := prepare(schema); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func preparePropertySchema(fieldVal interface{}, field reflect.StructField, propertySchema, parent *Schema) error {
+	fv := reflect.ValueOf(fieldVal)
+
+	if pp, ok := safeInterface(fv).(PropertyPreparer); ok {
+		return pp.PrepareJSONSchemaProperty(field, propertySchema, parent)
+	} else if pp, ok := ptrTo(fv).(PropertyPreparer); ok {
+		return pp.PrepareJSONSchemaProperty(field, propertySchema, parent)
+	}
+
+	return nil
+}
+
+func (r *Reflector) checkReflectingExposer(v reflect.Value, rc *ReflectContext, schema *Schema) (bool, error) {
+	var e ReflectingExposer
+
+	if exposer, ok := safeInterface(v).(ReflectingExposer); ok {
+		e = exposer
+	} else if exposer, ok := ptrTo(v).(ReflectingExposer); ok {
+		e = exposer
+	}
+
+	if e == nil {
+		return false, nil
+	}
+
+	s, err := e.JSONSchemaWith(func(sample interface{}) (Schema, error) {
+		rc.Path = append(rc.Path, "with")
+
+		return r.reflect(sample, rc, false, schema)
+	})
+	if err != nil {
+		return true, err
 	}
 
-	return schema, nil
+	*schema = s
+
+	return true, nil
+}
+
+func (r *Reflector) checkSchemaFileExposer(v reflect.Value, schema *Schema) (bool, error) {
+	var e SchemaFileExposer
+
+	if exposer, ok := safeInterface(v).(SchemaFileExposer); ok {
+		e = exposer
+	} else if exposer, ok := ptrTo(v).(SchemaFileExposer); ok {
+		e = exposer
+	}
+
+	if e == nil {
+		return false, nil
+	}
+
+	t := v.Type()
+
+	r.mu.Lock()
+	cached, found := r.schemaFiles[t]
+	r.mu.Unlock()
+
+	if !found {
+		fsys, name := e.JSONSchemaFile()
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return true, fmt.Errorf("reading schema file of %T: %w", e, err)
+		}
+
+		if err := json.Unmarshal(data, &cached); err != nil {
+			return true, fmt.Errorf("parsing schema file %s of %T: %w", name, e, err)
+		}
+
+		r.mu.Lock()
+		if r.schemaFiles == nil {
+			r.schemaFiles = map[reflect.Type]Schema{}
+		}
+
+		r.schemaFiles[t] = cached
+		r.mu.Unlock()
+	}
+
+	s, err := cached.JSONSchema()
+	if err != nil {
+		return true, err
+	}
+
+	*schema = s
+
+	return true, nil
 }
 
 func checkTextMarshaler(t reflect.Type, schema *Schema) bool {
@@ -557,11 +1052,44 @@ func checkTextMarshaler(t reflect.Type, schema *Schema) bool {
 	return false
 }
 
+// methodSet describes whether values of a type can implement interfaces.
+type methodSet struct {
+	// value is true if type has methods.
+	value bool
+
+	// pointer is true if pointer to type has methods that type itself does not have.
+	pointer bool
+}
+
+// methodSets caches methodSet by reflect.Type.
+var methodSets sync.Map
+
+func typeMethods(t reflect.Type) methodSet {
+	if ms, ok := methodSets.Load(t); ok {
+		return ms.(methodSet) //nolint:forcetypeassert // Type is checked.
+	}
+
+	ms := methodSet{
+		value:   t.NumMethod() > 0,
+		pointer: reflect.PtrTo(t).NumMethod() > t.NumMethod(),
+	}
+
+	methodSets.Store(t, ms)
+
+	return ms
+}
+
+// safeInterface returns value as interface to check implemented interfaces, nil pointer is replaced with
+// pointer to zero value, nil is returned if type has no methods.
 func safeInterface(v reflect.Value) interface{} {
 	if !v.IsValid() {
 		return nil
 	}
 
+	if v.Kind() != reflect.Interface && !typeMethods(v.Type()).value {
+		return nil
+	}
+
 	if v.Kind() == reflect.Ptr && !v.Elem().IsValid() {
 		v = reflect.New(v.Type().Elem())
 	}
@@ -569,8 +1097,10 @@ func safeInterface(v reflect.Value) interface{} {
 	return v.Interface()
 }
 
+// ptrTo returns pointer to a copy of value to check interfaces implemented with pointer receiver,
+// nil is returned if pointer has no methods in addition to methods of value.
 func ptrTo(v reflect.Value) interface{} {
-	if !v.IsValid() {
+	if !v.IsValid() || !typeMethods(v.Type()).pointer {
 		return nil
 	}
 
@@ -608,6 +1138,19 @@ func (r *Reflector) applySubSchemas(v reflect.Value, rc *ReflectContext, schema
 		schema.OneOf = schemas
 	}
 
+	var doe DiscriminatedOneOfExposer
+	if e, ok := vi.(DiscriminatedOneOfExposer); ok {
+		doe = e
+	} else if e, ok := vp.(DiscriminatedOneOfExposer); ok {
+		doe = e
+	}
+
+	if doe != nil {
+		if err := r.reflectDiscriminator(doe, rc, schema, oe == nil); err != nil {
+			return err
+		}
+	}
+
 	var ane AnyOfExposer
 	if e, ok := vi.(AnyOfExposer); ok {
 		ane = e
@@ -656,6 +1199,72 @@ func (r *Reflector) applySubSchemas(v reflect.Value, rc *ReflectContext, schema
 		schema.AllOf = schemas
 	}
 
+	var dse DependentSchemasExposer
+	if e, ok := vi.(DependentSchemasExposer); ok {
+		dse = e
+	} else if e, ok := vp.(DependentSchemasExposer); ok {
+		dse = e
+	}
+
+	if dse != nil {
+		dependentSchemas := dse.JSONSchemaDependentSchemas()
+		names := make([]string, 0, len(dependentSchemas))
+
+		for name := range dependentSchemas {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			rc.Path = append(rc.Path, "dependencies", name)
+
+			s, err := r.reflect(dependentSchemas[name], rc, false, schema)
+
+			rc.Path = rc.Path[:len(rc.Path)-1]
+
+			if err != nil {
+				return fmt.Errorf("failed to reflect 'dependencies' value %q of %T: %w", name, dse, err)
+			}
+
+			schema.WithDependenciesItem(name, DependenciesAdditionalProperties{
+				SchemaOrBool: &SchemaOrBool{TypeObject: &s},
+			})
+		}
+	}
+
+	var ppe PatternPropertiesExposer
+	if e, ok := vi.(PatternPropertiesExposer); ok {
+		ppe = e
+	} else if e, ok := vp.(PatternPropertiesExposer); ok {
+		ppe = e
+	}
+
+	if ppe != nil {
+		patternProperties := ppe.JSONSchemaPatternProperties()
+		patterns := make([]string, 0, len(patternProperties))
+
+		for pattern := range patternProperties {
+			patterns = append(patterns, pattern)
+		}
+
+		sort.Strings(patterns)
+
+		for _, pattern := range patterns {
+			rc.Path = append(rc.Path, "patternProperties", pattern)
+
+			s, err := r.reflect(patternProperties[pattern], rc, false, schema)
+
+			rc.Path = rc.Path[:len(rc.Path)-1]
+
+			if err != nil {
+				return fmt.Errorf("failed to reflect 'patternProperties' value %q of %T: %w", pattern, ppe, err)
+			}
+
+			schema.WithPatternPropertiesItem(pattern, s.ToSchemaOrBool())
+		}
+	}
+
 	var ne NotExposer
 	if e, ok := vi.(NotExposer); ok {
 		ne = e
@@ -700,7 +1309,7 @@ func (r *Reflector) applySubSchemas(v reflect.Value, rc *ReflectContext, schema
 	}
 
 	if te != nil {
-		rc.Path = append(rc.Path, "if")
+		rc.Path = append(rc.Path, "then")
 
 		s, err := r.reflect(te.JSONSchemaThen(), rc, false, schema)
 		if err != nil {
@@ -718,7 +1327,7 @@ func (r *Reflector) applySubSchemas(v reflect.Value, rc *ReflectContext, schema
 	}
 
 	if ee != nil {
-		rc.Path = append(rc.Path, "if")
+		rc.Path = append(rc.Path, "else")
 
 		s, err := r.reflect(ee.JSONSchemaElse(), rc, false, schema)
 		if err != nil {
@@ -728,10 +1337,106 @@ func (r *Reflector) applySubSchemas(v reflect.Value, rc *ReflectContext, schema
 		schema.WithElse(s.ToSchemaOrBool())
 	}
 
+	return r.applyConditional(vi, vp, rc, schema)
+}
+
+func (r *Reflector) applyConditional(vi, vp interface{}, rc *ReflectContext, schema *Schema) error {
+	var ce ConditionalExposer
+	if e, ok := vi.(ConditionalExposer); ok {
+		ce = e
+	} else if e, ok := vp.(ConditionalExposer); ok {
+		ce = e
+	}
+
+	if ce == nil {
+		return nil
+	}
+
+	ifv, thenv, elsev := ce.JSONSchemaIfThenElse()
+
+	for _, c := range []struct {
+		name   string
+		sample interface{}
+		setter func(SchemaOrBool) *Schema
+	}{
+		{name: "if", sample: ifv, setter: schema.WithIf},
+		{name: "then", sample: thenv, setter: schema.WithThen},
+		{name: "else", sample: elsev, setter: schema.WithElse},
+	} {
+		if c.sample == nil {
+			continue
+		}
+
+		rc.Path = append(rc.Path, c.name)
+
+		s, err := r.reflect(c.sample, rc, false, schema)
+		if err != nil {
+			return fmt.Errorf("failed to reflect '%s' value of %T: %w", c.name, ce, err)
+		}
+
+		c.setter(s.ToSchemaOrBool())
+	}
+
 	return nil
 }
 
-func (r *Reflector) isWellKnownType(t reflect.Type, schema *Schema) bool {
+func (r *Reflector) reflectDiscriminator(doe DiscriminatedOneOfExposer, rc *ReflectContext, schema *Schema, addOneOf bool) error {
+	propertyName, mapping := doe.JSONSchemaDiscriminator()
+	values := make([]string, 0, len(mapping))
+
+	for value := range mapping {
+		values = append(values, value)
+	}
+
+	sort.Strings(values)
+
+	d := Discriminator{PropertyName: propertyName}
+
+	for _, value := range values {
+		rc.Path = append(rc.Path, "oneOf")
+
+		s, err := r.reflect(mapping[value], rc, false, schema)
+		if err != nil {
+			return fmt.Errorf("failed to reflect discriminator mapping %q of %T: %w", value, doe, err)
+		}
+
+		if s.Ref != nil {
+			if d.Mapping == nil {
+				d.Mapping = make(map[string]string, len(values))
+			}
+
+			d.Mapping[value] = *s.Ref
+		}
+
+		if addOneOf {
+			schema.OneOf = append(schema.OneOf, s.ToSchemaOrBool())
+		}
+	}
+
+	schema.WithExtraPropertiesItem("discriminator", d)
+
+	return nil
+}
+
+func (r *Reflector) isWellKnownType(rc *ReflectContext, t reflect.Type, schema *Schema) bool {
+	if r.skipWellKnown[t] {
+		return false
+	}
+
+	if ws, ok := r.wellKnown[t]; ok {
+		nullable := schema.HasType(Null)
+		reflectType, parent := schema.ReflectType, schema.Parent
+
+		*schema = ws.Clone()
+		schema.ReflectType, schema.Parent = reflectType, parent
+
+		if nullable {
+			schema.AddType(Null)
+		}
+
+		return true
+	}
+
 	ts := refl.GoType(t)
 
 	switch ts {
@@ -743,6 +1448,25 @@ func (r *Reflector) isWellKnownType(t reflect.Type, schema *Schema) bool {
 		return true
 	}
 
+	if f, ok := protoWellKnownTypes[ts]; ok {
+		f(schema)
+
+		return true
+	}
+
+	switch ts {
+	case "github.com/shopspring/decimal.Decimal", "github.com/cockroachdb/apd.Decimal",
+		"github.com/cockroachdb/apd/v2::apd.Decimal", "github.com/cockroachdb/apd/v3::apd.Decimal":
+		decimalSchema(rc, schema)
+
+		return true
+	case "github.com/shopspring/decimal.NullDecimal":
+		decimalSchema(rc, schema)
+		schema.AddType(Null)
+
+		return true
+	}
+
 	if t == typeOfByteSlice {
 		schema.AddType(String)
 		schema.WithFormat("base64")
@@ -764,13 +1488,129 @@ func (r *Reflector) isWellKnownType(t reflect.Type, schema *Schema) bool {
 		return true
 	}
 
+	if t == typeOfNetIP || t == typeOfNetipAddr {
+		schema.AddType(String)
+		schema.AnyOf = []SchemaOrBool{
+			(&Schema{}).WithFormat("ipv4").ToSchemaOrBool(),
+			(&Schema{}).WithFormat("ipv6").ToSchemaOrBool(),
+		}
+
+		return true
+	}
+
+	if t == typeOfURL {
+		schema.AddType(String)
+		schema.WithFormat("uri")
+
+		return true
+	}
+
+	if t == typeOfJSONNumber {
+		types := rc.JSONNumberTypes
+		if len(types) == 0 {
+			types = []SimpleType{Number}
+		}
+
+		for _, st := range types {
+			schema.AddType(st)
+		}
+
+		return true
+	}
+
+	if r.isBigNumberType(rc, t, schema) {
+		return true
+	}
+
+	if st, ok := sqlNullTypes[t]; ok {
+		schema.AddType(st)
+		schema.AddType(Null)
+
+		if t == typeOfSQLNullTime {
+			schema.WithFormat("date-time")
+		}
+
+		return true
+	}
+
+	if t == typeOfDuration && rc.DurationAsString {
+		schema.AddType(String)
+		schema.WithFormat("duration")
+		schema.WithExamples("1h30m")
+
+		return true
+	}
+
+	if t == typeOfFileHeader || t == typeOfMultipartFile ||
+		(rc.ReaderAsBinary && t.Kind() == reflect.Interface && t.Implements(typeOfReader)) {
+		schema.AddType(String)
+		schema.WithFormat("binary")
+
+		return true
+	}
+
 	return false
 }
 
-var baseNameRegex = regexp.MustCompile(`\[(.+\/)*([^\/]+)·\d+\]`)
+// decimalSchema describes arbitrary-precision decimal type, it is a string unless DecimalAsNumber is enabled.
+func decimalSchema(rc *ReflectContext, schema *Schema) {
+	if rc.DecimalAsNumber {
+		schema.AddType(Number)
+
+		return
+	}
+
+	schema.AddType(String)
+	schema.WithFormat("decimal")
+	schema.WithPattern(`^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+}
+
+// isBigNumberType checks math/big types, they are reflected as numbers or as strings with BigTypesAsStrings.
+func (r *Reflector) isBigNumberType(rc *ReflectContext, t reflect.Type, schema *Schema) bool {
+	switch {
+	case t == typeOfBigRat:
+		schema.AddType(String)
+		schema.WithPattern(`^-?[0-9]+(/[0-9]+)?$`)
+	case t == typeOfBigInt && rc.BigTypesAsStrings:
+		schema.AddType(String)
+		schema.WithPattern(`^-?[0-9]+$`)
+	case t == typeOfBigInt:
+		schema.AddType(Integer)
+	case t == typeOfBigFloat:
+		// Value is marshaled as text.
+		schema.AddType(String)
+		schema.WithPattern(`^[-+]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][-+]?[0-9]+)?$`)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// sqlNullTypes maps nullable types of database/sql to types of their values.
+var sqlNullTypes = map[reflect.Type]SimpleType{
+	reflect.TypeOf(sql.NullString{}):  String,
+	reflect.TypeOf(sql.NullInt64{}):   Integer,
+	reflect.TypeOf(sql.NullInt32{}):   Integer,
+	reflect.TypeOf(sql.NullInt16{}):   Integer,
+	reflect.TypeOf(sql.NullByte{}):    Integer,
+	reflect.TypeOf(sql.NullFloat64{}): Number,
+	reflect.TypeOf(sql.NullBool{}):    Boolean,
+	reflect.TypeOf(sql.NullTime{}):    String,
+}
+
+var baseNameRegex = regexp.MustCompile(`\[(.+\/)*([^\/]+)·\d+\]`)
+
+func (r *Reflector) defName(rc *ReflectContext, t reflect.Type) string {
+	if t.PkgPath() == "" || t == typeOfTime || t == typeOfJSONRawMsg || t == typeOfDate {
+		return ""
+	}
+
+	if _, ok := sqlNullTypes[t]; ok || t == typeOfJSONNumber || (t == typeOfDuration && rc.DurationAsString) {
+		return ""
+	}
 
-func (r *Reflector) defName(rc *ReflectContext, t reflect.Type) string {
-	if t.PkgPath() == "" || t == typeOfTime || t == typeOfJSONRawMsg || t == typeOfDate {
+	if isProtoWellKnownType(t) {
 		return ""
 	}
 
@@ -782,6 +1622,9 @@ func (r *Reflector) defName(rc *ReflectContext, t reflect.Type) string {
 		return ""
 	}
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if r.defNameTypes == nil {
 		r.defNameTypes = map[string]reflect.Type{}
 	}
@@ -828,21 +1671,51 @@ func (r *Reflector) defName(rc *ReflectContext, t reflect.Type) string {
 	}
 }
 
+// customDefName registers explicitly requested definition name, resolving conflicts with other types.
+func (r *Reflector) customDefName(t reflect.Type, name string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.defNameTypes == nil {
+		r.defNameTypes = map[string]reflect.Type{}
+	}
+
+	defName := name
+
+	for try := 2; ; try++ {
+		if tt, found := r.defNameTypes[defName]; !found || tt == t {
+			r.defNameTypes[defName] = t
+
+			return defName
+		}
+
+		defName = name + "Type" + strconv.Itoa(try)
+	}
+}
+
 func (r *Reflector) kindSwitch(t reflect.Type, v reflect.Value, schema *Schema, rc *ReflectContext) error {
 	//nolint:exhaustive // Covered with default case.
 	switch t.Kind() {
 	case reflect.Struct:
 		switch {
-		case reflect.PtrTo(t).Implements(typeOfTextUnmarshaler):
+		case reflect.PtrTo(t).Implements(typeOfTextUnmarshaler) && !r.ignoresTextMarshaler(t):
 			schema.AddType(String)
+		case t.Implements(typeOfTupleStruct) || reflect.PtrTo(t).Implements(typeOfTupleStruct):
+			return r.reflectTuple(v, schema, rc)
 		default:
 			schema.AddType(Object)
 			removeNull(schema.Type)
 
+			allOf := len(schema.AllOf)
+
 			err := r.walkProperties(v, schema, rc)
 			if err != nil {
 				return err
 			}
+
+			if rc.ForbidUnevaluatedProperties && len(schema.AllOf) > allOf {
+				forbidUnevaluatedProperties(schema)
+			}
 		}
 
 	case reflect.Slice, reflect.Array:
@@ -863,7 +1736,7 @@ func (r *Reflector) kindSwitch(t reflect.Type, v reflect.Value, schema *Schema,
 			v = v.Elem()
 		}
 
-		if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Len() > 0 {
+		if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Len() > 0 && !rc.SkipElementSamples {
 			itemValue = v.Index(0).Interface()
 		}
 
@@ -875,6 +1748,12 @@ func (r *Reflector) kindSwitch(t reflect.Type, v reflect.Value, schema *Schema,
 		schema.AddType(Array)
 		schema.WithItems(*(&Items{}).WithSchemaOrBool(itemsSchema.ToSchemaOrBool()))
 
+		// Fixed size array has exact number of items.
+		if t.Kind() == reflect.Array {
+			schema.WithMinItems(int64(t.Len()))
+			schema.WithMaxItems(int64(t.Len()))
+		}
+
 	case reflect.Map:
 		elemType := t.Elem()
 
@@ -889,7 +1768,7 @@ func (r *Reflector) kindSwitch(t reflect.Type, v reflect.Value, schema *Schema,
 			v = v.Elem()
 		}
 
-		if v.Kind() == reflect.Map {
+		if v.Kind() == reflect.Map && !rc.SkipElementSamples {
 			rng := v.MapRange()
 			for rng.Next() {
 				itemValue = rng.Value().Interface()
@@ -919,6 +1798,17 @@ func (r *Reflector) kindSwitch(t reflect.Type, v reflect.Value, schema *Schema,
 		schema.AddType(String)
 	case reflect.Interface:
 		schema.Type = nil
+
+		for _, impl := range r.interfaceImpls[t] {
+			rc.Path = append(rc.Path, "oneOf")
+
+			s, err := r.reflect(impl, rc, false, schema)
+			if err != nil {
+				return fmt.Errorf("failed to reflect implementation %T of %s: %w", impl, t.String(), err)
+			}
+
+			schema.OneOf = append(schema.OneOf, s.ToSchemaOrBool())
+		}
 	default:
 		if rc.SkipUnsupportedProperties {
 			return ErrSkipProperty
@@ -975,7 +1865,42 @@ func (r *Reflector) propertyTag(rc *ReflectContext, field reflect.StructField) (
 	return "", false
 }
 
-func (r *Reflector) makeFields(v reflect.Value) ([]reflect.StructField, []reflect.Value) {
+// reflectTuple populates schema with tuple items of exported struct fields.
+func (r *Reflector) reflectTuple(v reflect.Value, schema *Schema, rc *ReflectContext) error {
+	fields, values, _ := r.makeFields(v)
+	items := make([]SchemaOrBool, 0, len(fields))
+
+	for i, field := range fields {
+		if field.PkgPath != "" || field.Tag.Get("json") == "-" {
+			continue
+		}
+
+		rc.Path = append(rc.Path, field.Name)
+
+		itemSchema, err := r.reflect(values[i].Interface(), rc, false, schema)
+		if err != nil {
+			return err
+		}
+
+		if err := refl.PopulateFieldsFromTags(&itemSchema, rc.mapTag(field.Tag)); err != nil {
+			return fmt.Errorf("%s: %w", field.Name, err)
+		}
+
+		items = append(items, itemSchema.ToSchemaOrBool())
+	}
+
+	schema.AddType(Array)
+	removeNull(schema.Type)
+	schema.WithItems(*(&Items{}).WithSchemaArray(items...))
+	schema.WithAdditionalItems(SchemaOrBool{TypeBoolean: new(bool)})
+	schema.WithMinItems(int64(len(items)))
+	schema.WithMaxItems(int64(len(items)))
+
+	return nil
+}
+
+// makeFields returns fields and values of struct, and Field.Prepare functions of virtual struct.
+func (r *Reflector) makeFields(v reflect.Value) ([]reflect.StructField, []reflect.Value, []func(*Schema) error) {
 	t := v.Type()
 	for t.Kind() == reflect.Ptr {
 		t = t.Elem()
@@ -988,8 +1913,9 @@ func (r *Reflector) makeFields(v reflect.Value) ([]reflect.StructField, []reflec
 	}
 
 	var (
-		fields []reflect.StructField
-		values []reflect.Value
+		fields   []reflect.StructField
+		values   []reflect.Value
+		prepares []func(*Schema) error
 	)
 
 	isVirtualStruct := false
@@ -1003,9 +1929,20 @@ func (r *Reflector) makeFields(v reflect.Value) ([]reflect.StructField, []reflec
 				field.Name = f.Name
 				field.Tag = f.Tag
 				field.Type = reflect.TypeOf(f.Value)
+				value := reflect.ValueOf(f.Value)
+
+				if f.Schema != nil {
+					value = reflect.ValueOf(fieldSchema{schema: f.Schema})
+					field.Type = value.Type()
+				}
+
+				if f.Required {
+					field.Tag = `required:"true" ` + f.Tag
+				}
 
 				fields = append(fields, field)
-				values = append(values, reflect.ValueOf(f.Value))
+				values = append(values, value)
+				prepares = append(prepares, f.Prepare)
 			}
 		}
 	}
@@ -1017,13 +1954,17 @@ func (r *Reflector) makeFields(v reflect.Value) ([]reflect.StructField, []reflec
 		}
 	}
 
-	return fields, values
+	return fields, values, prepares
 }
 
 func (r *Reflector) walkProperties(v reflect.Value, parent *Schema, rc *ReflectContext) error {
-	fields, values := r.makeFields(v)
+	fields, values, prepares := r.makeFields(v)
+	overrides := r.fieldOverrides[refl.DeepIndirect(v.Type())]
+
+	var conditions []fieldCondition
 
 	for i, field := range fields {
+		field.Tag = rc.mapTag(field.Tag)
 		tag, tagFound := r.propertyTag(rc, field)
 
 		// Skip explicitly discarded field.
@@ -1070,6 +2011,10 @@ func (r *Reflector) walkProperties(v reflect.Value, parent *Schema, rc *ReflectC
 				parent.AdditionalProperties = &SchemaOrBool{TypeBoolean: additionalProperties}
 			}
 
+			if required, ok := field.Tag.Lookup("required"); ok {
+				addRequired(parent, strings.Split(required, ",")...)
+			}
+
 			if !rc.SkipNonConstraints {
 				if err := reflectExamples(rc, parent, field); err != nil {
 					return err
@@ -1107,6 +2052,13 @@ func (r *Reflector) walkProperties(v reflect.Value, parent *Schema, rc *ReflectC
 			return err
 		}
 
+		inline := false
+		if err := refl.ReadBoolTag(field.Tag, "inline", &inline); err != nil {
+			return err
+		}
+
+		customDefName := field.Tag.Get("defName")
+
 		if required {
 			parent.Required = append(parent.Required, propName)
 		}
@@ -1134,38 +2086,73 @@ func (r *Reflector) walkProperties(v reflect.Value, parent *Schema, rc *ReflectC
 			}
 		}
 
-		propertySchema, err := r.reflect(fieldVal, rc, true, parent)
-		if err != nil {
-			if errors.Is(err, ErrSkipProperty) {
-				continue
-			}
+		var (
+			propertySchema Schema
+			err            error
+		)
 
-			return err
+		if fs, ok := fieldVal.(fieldSchema); ok {
+			propertySchema = fs.schema.Clone()
+			propertySchema.Parent = parent
+			rc.Path = rc.Path[:len(rc.Path)-1]
+
+			// Explicit schema is only made nullable with `nullable` tag.
+			omitEmpty = true
+		} else {
+			rc.inlineNext = inline
+			rc.defNameNext = customDefName
+
+			propertySchema, err = r.reflect(fieldVal, rc, true, parent)
+			if err != nil {
+				if errors.Is(err, ErrSkipProperty) {
+					continue
+				}
+
+				return err
+			}
 		}
 
 		checkNullability(&propertySchema, rc, ft, omitEmpty, nullable)
 
 		if !rc.SkipNonConstraints {
-			err = checkInlineValue(&propertySchema, field, "default", propertySchema.WithDefault)
+			err = checkInlineValue(rc, &propertySchema, field, "default", propertySchema.WithDefault)
 			if err != nil {
 				return fmt.Errorf("%s: %w", strings.Join(append(rc.Path[1:], field.Name), "."), err)
 			}
 		}
 
-		err = checkInlineValue(&propertySchema, field, "const", propertySchema.WithConst)
+		err = checkInlineValue(rc, &propertySchema, field, "const", propertySchema.WithConst)
 		if err != nil {
 			return err
 		}
 
+		if layout := field.Tag.Get("timeFormat"); layout != "" {
+			timeFormat(&propertySchema, layout)
+		}
+
 		if err := refl.PopulateFieldsFromTags(&propertySchema, field.Tag); err != nil {
 			return err
 		}
 
+		if rc.BigNumbers {
+			bigNumbers(&propertySchema, field.Tag)
+		}
+
+		if rc.StrictTags {
+			if err := r.checkStrictTags(rc, field, &propertySchema); err != nil {
+				return err
+			}
+		}
+
 		deprecated := false
 		if err := refl.ReadBoolTag(field.Tag, "deprecated", &deprecated); err != nil {
 			return err
 		} else if deprecated {
 			propertySchema.WithExtraPropertiesItem("deprecated", true)
+
+			if msg := field.Tag.Get("deprecatedMsg"); msg != "" {
+				propertySchema.WithExtraPropertiesItem(rc.deprecatedReasonProperty(), msg)
+			}
 		}
 
 		if !rc.SkipNonConstraints {
@@ -1174,13 +2161,33 @@ func (r *Reflector) walkProperties(v reflect.Value, parent *Schema, rc *ReflectC
 			}
 		}
 
-		reflectEnum(&propertySchema, field.Tag, nil)
+		if err := reflectEnum(&propertySchema, field.Tag, nil); err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(append(rc.Path[1:], propName), "."), err)
+		}
+
+		if rc.ExclusiveBoundsDraft04 {
+			exclusiveBoundsDraft04(&propertySchema)
+		}
 
 		// Remove temporary kept type from referenced schema.
 		if propertySchema.Ref != nil {
 			propertySchema.Type = nil
 		}
 
+		if err := preparePropertySchema(fieldVal, field, &propertySchema, parent); err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(append(rc.Path[1:], propName), "."), err)
+		}
+
+		if prepares != nil && prepares[i] != nil {
+			if err := prepares[i](&propertySchema); err != nil {
+				return fmt.Errorf("%s: %w", strings.Join(append(rc.Path[1:], propName), "."), err)
+			}
+		}
+
+		if override, ok := overrides[field.Name]; ok {
+			mergeSchema(&propertySchema, override.Clone())
+		}
+
 		if rc.interceptProp != nil {
 			if err := rc.interceptProp(InterceptPropParams{
 				Context:        rc,
@@ -1199,6 +2206,22 @@ func (r *Reflector) walkProperties(v reflect.Value, parent *Schema, rc *ReflectC
 			}
 		}
 
+		if rc.VerifyExamples && (propertySchema.Default != nil || len(propertySchema.Examples) > 0) {
+			rc.valueChecks = append(rc.valueChecks, valueCheck{
+				path:   strings.Join(append(rc.Path[1:], field.Name), "."),
+				schema: propertySchema.Clone(),
+			})
+		}
+
+		if when, ok := field.Tag.Lookup("when"); ok {
+			c, err := parseWhenTag(strings.Join(append(rc.Path[1:], propName), "."), propName, when)
+			if err != nil {
+				return err
+			}
+
+			conditions = append(conditions, c)
+		}
+
 		if parent.Properties == nil {
 			parent.Properties = make(map[string]SchemaOrBool, 1)
 		}
@@ -1208,10 +2231,16 @@ func (r *Reflector) walkProperties(v reflect.Value, parent *Schema, rc *ReflectC
 		}
 	}
 
-	return nil
+	return applyConditions(parent, conditions)
 }
 
-func checkInlineValue(propertySchema *Schema, field reflect.StructField, tag string, setter func(interface{}) *Schema) error {
+func checkInlineValue(
+	rc *ReflectContext,
+	propertySchema *Schema,
+	field reflect.StructField,
+	tag string,
+	setter func(interface{}) *Schema,
+) error {
 	var (
 		val interface{}
 		t   SimpleType
@@ -1233,7 +2262,11 @@ func checkInlineValue(propertySchema *Schema, field reflect.StructField, tag str
 
 	switch {
 	case propertySchema.HasType(Number) && f != nil:
-		val = *f
+		if rc.BigNumbers && !isExactFloat(*s, *f) {
+			val = json.Number(*s)
+		} else {
+			val = *f
+		}
 	case propertySchema.HasType(Integer) && i != nil:
 		val = *i
 	case propertySchema.HasType(Boolean) && b != nil:
@@ -1251,15 +2284,40 @@ func checkInlineValue(propertySchema *Schema, field reflect.StructField, tag str
 			break
 		}
 
-		err := json.Unmarshal([]byte(v), &val)
+		var itemsSchema *Schema
+
+		if strings.HasPrefix(v, "[") && strings.HasSuffix(v, "]") &&
+			propertySchema.Items != nil &&
+			propertySchema.Items.SchemaOrBool != nil {
+			itemsSchema = propertySchema.Items.SchemaOrBool.TypeObject
+		}
+
+		err := unmarshalValue(rc, v, &val)
 		if err == nil {
+			if list, ok := val.([]interface{}); ok && itemsSchema != nil {
+				if err := checkTypedList(list, itemsSchema); err != nil {
+					return fmt.Errorf("parsing %s: %w", tag, err)
+				}
+			}
+
 			break
 		}
 
-		if strings.HasPrefix(v, "[") && strings.HasSuffix(v, "]") &&
-			propertySchema.Items != nil &&
-			propertySchema.Items.SchemaOrBool != nil &&
-			propertySchema.Items.SchemaOrBool.TypeObject.HasType(String) {
+		// Not a JSON value, bracketed comma-separated list of scalars is parsed by items type, e.g. `[1, 2]`.
+		if itemsSchema != nil {
+			list, err := parseTypedList(v[1:len(v)-1], itemsSchema)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", tag, err)
+			}
+
+			if list != nil {
+				val = list
+
+				break
+			}
+		}
+
+		if itemsSchema != nil && itemsSchema.HasType(String) {
 			val = strings.Split(v[1:len(v)-1], ",")
 
 			break
@@ -1275,6 +2333,183 @@ func checkInlineValue(propertySchema *Schema, field reflect.StructField, tag str
 	return nil
 }
 
+// scalarParser returns a parser of string value for integer, number or boolean schema.
+func scalarParser(schema *Schema) (SimpleType, func(s string) (interface{}, error)) {
+	switch {
+	case schema == nil:
+		return "", nil
+	case schema.HasType(Integer):
+		return Integer, func(s string) (interface{}, error) { return strconv.ParseInt(s, 10, 64) }
+	case schema.HasType(Number):
+		return Number, func(s string) (interface{}, error) { return strconv.ParseFloat(s, 64) }
+	case schema.HasType(Boolean):
+		return Boolean, func(s string) (interface{}, error) { return strconv.ParseBool(s) }
+	}
+
+	return "", nil
+}
+
+// addRequired appends property names to required list of schema, skipping empty and existing names.
+func addRequired(schema *Schema, names ...string) {
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		found := false
+
+		for _, r := range schema.Required {
+			if r == name {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// unmarshalValue decodes JSON value, numbers are decoded as json.Number if ReflectContext.BigNumbers is enabled.
+func unmarshalValue(rc *ReflectContext, v string, val *interface{}) error {
+	if !rc.BigNumbers {
+		return json.Unmarshal([]byte(v), val)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(v))
+	dec.UseNumber()
+
+	if err := dec.Decode(val); err != nil {
+		return err
+	}
+
+	if dec.More() {
+		return errors.New("unexpected data after JSON value")
+	}
+
+	return nil
+}
+
+// isExactFloat checks if float64 value represents original number without loss of precision.
+func isExactFloat(orig string, f float64) bool {
+	o, _, err := big.ParseFloat(strings.TrimSpace(orig), 10, 512, big.ToNearestEven)
+	if err != nil {
+		return true
+	}
+
+	r, _, err := big.ParseFloat(strconv.FormatFloat(f, 'g', -1, 64), 10, 512, big.ToNearestEven)
+	if err != nil {
+		return true
+	}
+
+	return o.Cmp(r) == 0
+}
+
+// bigNumberKeywords lists numeric keywords that are checked for precision loss with ReflectContext.BigNumbers.
+var bigNumberKeywords = []string{"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"}
+
+// bigNumbers adds json.Number values of numeric keywords that lose precision as float64 to extra properties.
+//
+// Typed fields are kept for consumers of schema, JSON encoding takes precise values from extra properties.
+func bigNumbers(schema *Schema, tag reflect.StructTag) {
+	for _, keyword := range bigNumberKeywords {
+		v, ok := tag.Lookup(keyword)
+		if !ok {
+			continue
+		}
+
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || isExactFloat(v, f) {
+			continue
+		}
+
+		schema.WithExtraPropertiesItem(keyword, json.Number(v))
+	}
+}
+
+// exclusiveBoundsDraft04 converts numeric exclusive bounds to draft-04 boolean flags.
+func exclusiveBoundsDraft04(schema *Schema) {
+	if schema.ExclusiveMinimum != nil {
+		schema.Minimum = schema.ExclusiveMinimum
+		schema.ExclusiveMinimum = nil
+		schema.WithExtraPropertiesItem("exclusiveMinimum", true)
+	}
+
+	if schema.ExclusiveMaximum != nil {
+		schema.Maximum = schema.ExclusiveMaximum
+		schema.ExclusiveMaximum = nil
+		schema.WithExtraPropertiesItem("exclusiveMaximum", true)
+	}
+}
+
+// forbidUnevaluatedProperties replaces `additionalProperties: false` with `unevaluatedProperties: false`.
+func forbidUnevaluatedProperties(schema *Schema) {
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.TypeBoolean != nil &&
+		!*schema.AdditionalProperties.TypeBoolean {
+		schema.AdditionalProperties = nil
+	}
+
+	schema.WithUnevaluatedProperties(SchemaOrBool{TypeBoolean: new(bool)})
+}
+
+// parseTypedList parses comma-separated list of integer, number or boolean items.
+//
+// Nil result is returned if items schema is not of a scalar non-string type.
+func parseTypedList(v string, itemsSchema *Schema) ([]interface{}, error) {
+	typeName, parse := scalarParser(itemsSchema)
+	if parse == nil {
+		return nil, nil
+	}
+
+	list := make([]interface{}, 0)
+
+	if strings.TrimSpace(v) == "" {
+		return list, nil
+	}
+
+	for _, item := range strings.Split(v, ",") {
+		item = strings.TrimSpace(item)
+
+		parsed, err := parse(item)
+		if err != nil {
+			return nil, fmt.Errorf("item %q is not a valid %s", item, typeName)
+		}
+
+		list = append(list, parsed)
+	}
+
+	return list, nil
+}
+
+// checkTypedList checks that items of JSON list match integer, number or boolean items schema.
+func checkTypedList(list []interface{}, itemsSchema *Schema) error {
+	typeName, parse := scalarParser(itemsSchema)
+	if parse == nil {
+		return nil
+	}
+
+	for _, item := range list {
+		if item == nil && itemsSchema.HasType(Null) {
+			continue
+		}
+
+		// Strings, objects and arrays are JSON encoded with characters that fail scalar parsing.
+		j, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+
+		if _, err := parse(string(j)); err != nil {
+			return fmt.Errorf("item %s is not a valid %s", j, typeName)
+		}
+	}
+
+	return nil
+}
+
 // checkNullability checks Go semantic conditions and adds null type to schemas when appropriate.
 //
 // Presence of `omitempty` field tag disables nullability for the reason that marshaled value
@@ -1304,7 +2539,11 @@ func checkNullability(propertySchema *Schema, rc *ReflectContext, ft reflect.Typ
 
 	if nullable != nil {
 		if *nullable {
-			propertySchema.AddType(Null)
+			if propertySchema.Ref != nil {
+				envelopNull(propertySchema)
+			} else {
+				propertySchema.AddType(Null)
+			}
 
 			in.NullAdded = true
 		} else if propertySchema.Ref == nil && propertySchema.HasType(Null) {
@@ -1320,7 +2559,8 @@ func checkNullability(propertySchema *Schema, rc *ReflectContext, ft reflect.Typ
 		return
 	}
 
-	if propertySchema.HasType(Array) ||
+	// Tuple struct (see TupleStruct) is an array that can not be null.
+	if (propertySchema.HasType(Array) && ft.Kind() != reflect.Struct) ||
 		(propertySchema.HasType(Object) && len(propertySchema.Properties) == 0 && propertySchema.Ref == nil) {
 		propertySchema.AddType(Null)
 
@@ -1339,17 +2579,24 @@ func checkNullability(propertySchema *Schema, rc *ReflectContext, ft reflect.Typ
 
 		if (def.HasType(Array) || def.HasType(Object) || ft.Kind() == reflect.Ptr) && !def.HasType(Null) {
 			if rc.EnvelopNullability {
-				refSchema := *propertySchema
-				propertySchema.Ref = nil
-				propertySchema.AnyOf = []SchemaOrBool{
-					Null.ToSchemaOrBool(),
-					refSchema.ToSchemaOrBool(),
-				}
+				envelopNull(propertySchema)
 			}
 		}
 	}
 }
 
+// envelopNull replaces reference with `"anyOf":[{"type":"null"},{"$ref":"..."}]`.
+func envelopNull(propertySchema *Schema) {
+	refSchema := *propertySchema
+	refSchema.Type = nil // Remove temporary kept type from referenced schema.
+	propertySchema.Ref = nil
+	propertySchema.Type = nil
+	propertySchema.AnyOf = []SchemaOrBool{
+		Null.ToSchemaOrBool(),
+		refSchema.ToSchemaOrBool(),
+	}
+}
+
 func reflectExamples(rc *ReflectContext, propertySchema *Schema, field reflect.StructField) error {
 	if err := reflectExample(rc, propertySchema, field); err != nil {
 		return err
@@ -1371,7 +2618,7 @@ func reflectExamples(rc *ReflectContext, propertySchema *Schema, field reflect.S
 }
 
 func reflectExample(rc *ReflectContext, propertySchema *Schema, field reflect.StructField) error {
-	err := checkInlineValue(propertySchema, field, "example", func(i interface{}) *Schema {
+	err := checkInlineValue(rc, propertySchema, field, "example", func(i interface{}) *Schema {
 		return propertySchema.WithExamples(i)
 	})
 	if err != nil {
@@ -1381,32 +2628,56 @@ func reflectExample(rc *ReflectContext, propertySchema *Schema, field reflect.St
 	return nil
 }
 
-func reflectEnum(schema *Schema, fieldTag reflect.StructTag, fieldVal interface{}) {
+func reflectEnum(schema *Schema, fieldTag reflect.StructTag, fieldVal interface{}) error {
 	enum := enum{}
-	enum.loadFromField(fieldTag, fieldVal)
+	if err := enum.loadFromField(fieldTag, fieldVal, schema); err != nil {
+		return err
+	}
 
-	if len(enum.items) > 0 {
-		schema.Enum = enum.items
-		if len(enum.names) > 0 {
-			if schema.ExtraProperties == nil {
-				schema.ExtraProperties = make(map[string]interface{}, 1)
-			}
+	enum.apply(schema)
 
-			schema.ExtraProperties[XEnumNames] = enum.names
-		}
-	}
+	return nil
 }
 
 // enum can be use for sending enum data that need validate.
 type enum struct {
-	items []interface{}
-	names []string
+	items        []interface{}
+	names        []string
+	descriptions []string
+}
+
+// apply sets enum items and names to schema.
+func (enum enum) apply(schema *Schema) {
+	if len(enum.items) == 0 {
+		return
+	}
+
+	schema.Enum = enum.items
+	if len(enum.names) > 0 {
+		if schema.ExtraProperties == nil {
+			schema.ExtraProperties = make(map[string]interface{}, 1)
+		}
+
+		schema.ExtraProperties[XEnumNames] = enum.names
+	}
+
+	if len(enum.descriptions) > 0 {
+		schema.WithExtraPropertiesItem(XEnumDescriptions, enum.descriptions)
+	}
 }
 
 // loadFromField loads enum from field tag: json array or comma-separated string.
-func (enum *enum) loadFromField(fieldTag reflect.StructTag, fieldVal interface{}) {
+//
+// Comma-separated values are converted according to the type of schema (integer, number or boolean).
+func (enum *enum) loadFromField(fieldTag reflect.StructTag, fieldVal interface{}, schema *Schema) error {
 	fv := reflect.ValueOf(fieldVal)
 
+	if e, isEnumer := safeInterface(fv).(DescribedEnum); isEnumer {
+		enum.items, enum.names, enum.descriptions = e.DescribedEnum()
+	} else if e, isEnumer := ptrTo(fv).(DescribedEnum); isEnumer {
+		enum.items, enum.names, enum.descriptions = e.DescribedEnum()
+	}
+
 	if e, isEnumer := safeInterface(fv).(NamedEnum); isEnumer {
 		enum.items, enum.names = e.NamedEnum()
 	} else if e, isEnumer := ptrTo(fv).(NamedEnum); isEnumer {
@@ -1422,18 +2693,41 @@ func (enum *enum) loadFromField(fieldTag reflect.StructTag, fieldVal interface{}
 	if enumTag := fieldTag.Get("enum"); enumTag != "" {
 		var e []interface{}
 
-		err := json.Unmarshal([]byte(enumTag), &e)
-		if err != nil {
-			es := strings.Split(enumTag, ",")
-			e = make([]interface{}, len(es))
-
-			for i, s := range es {
-				e[i] = s
+		if err := json.Unmarshal([]byte(enumTag), &e); err != nil {
+			if e, err = splitEnum(enumTag, schema); err != nil {
+				return err
 			}
 		}
 
 		enum.items = e
 	}
+
+	return nil
+}
+
+// splitEnum parses comma-separated enum values, values are converted to integer, number or boolean schema type.
+func splitEnum(enumTag string, schema *Schema) ([]interface{}, error) {
+	es := strings.Split(enumTag, ",")
+	e := make([]interface{}, len(es))
+
+	t, parse := scalarParser(schema)
+
+	for i, s := range es {
+		if parse == nil {
+			e[i] = s
+
+			continue
+		}
+
+		v, err := parse(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("parsing enum value %q as %s: %w", s, t, err)
+		}
+
+		e[i] = v
+	}
+
+	return e, nil
 }
 
 type (
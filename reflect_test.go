@@ -2,12 +2,21 @@ package jsonschema_test
 
 import (
 	"context"
+	"database/sql"
 	"encoding"
 	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"math/big"
 	"mime/multipart"
+	"net"
+	"net/netip"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -89,20 +98,7 @@ func TestReflector_Reflect_namedInterface(t *testing.T) {
 	}
 
 	reflector := jsonschema.Reflector{}
-	schema, err := reflector.Reflect(s{}, jsonschema.InterceptSchema(
-		func(params jsonschema.InterceptSchemaParams) (stop bool, err error) {
-			assert.NotNil(t, params.Context)
-
-			if _, ok := params.Value.Interface().(*multipart.File); ok {
-				params.Schema.AddType(jsonschema.String)
-				params.Schema.WithFormat("binary")
-
-				return true, nil
-			}
-
-			return false, nil
-		},
-	))
+	schema, err := reflector.Reflect(s{})
 	require.NoError(t, err)
 
 	assertjson.EqualMarshal(t, []byte(`{
@@ -1833,6 +1829,52 @@ func TestReflector_Reflect_nullable(t *testing.T) {
 	}`, s)
 }
 
+func TestReflector_Reflect_nullableRef(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type Item struct {
+		Foo string `json:"foo"`
+	}
+
+	type My struct {
+		Nullable Item `json:"nullable" nullable:"true"`
+		Plain    Item `json:"plain"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestItem":{"properties":{"foo":{"type":"string"}},"type":"object"}
+	  },
+	  "properties":{
+		"nullable":{"anyOf":[{"type":"null"},{"$ref":"#/definitions/JsonschemaGoTestItem"}]},
+		"plain":{"$ref":"#/definitions/JsonschemaGoTestItem"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestReflector_Reflect_parentRequired(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Foo string   `json:"foo" required:"true"`
+		Bar int      `json:"bar"`
+		_   struct{} `required:"foo, bar,extra"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "required":["foo","bar","extra"],
+	  "properties":{"bar":{"type":"integer"},"foo":{"type":"string"}},
+	  "type":"object"
+	}`, s)
+}
+
 func TestReflector_Reflect_customTags(t *testing.T) {
 	r := jsonschema.Reflector{}
 
@@ -1878,6 +1920,273 @@ func TestReflector_Reflect_customTags(t *testing.T) {
 	}`, s)
 }
 
+func TestReflector_Reflect_tagMapping(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Foo string   `json:"foo" maxlen:"10" desc:"This is foo." minLength:"2" minlen:"3"`
+		Bar int      `json:"bar" min:"1" max:"5" req:"true"`
+		_   struct{} `desc:"This is My."`
+	}
+
+	s, err := r.Reflect(My{}, jsonschema.TagMapping(map[string]string{
+		"minimum":     "min",
+		"maximum":     "max",
+		"maxLength":   "maxlen",
+		"minLength":   "minlen",
+		"description": "desc",
+		"required":    "req",
+	}))
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "required":["bar"],"description":"This is My.",
+	  "properties":{
+		"bar":{"maximum":5,"minimum":1,"type":"integer"},
+		"foo":{"description":"This is foo.","maxLength":10,"minLength":2,"type":"string"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestReflector_Reflect_inlineTag(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type Inner struct {
+		Baz int `json:"baz"`
+	}
+
+	type Item struct {
+		Foo   string `json:"foo"`
+		Inner Inner  `json:"inner"`
+	}
+
+	type My struct {
+		Ref     Item            `json:"ref"`
+		Inlined *Item           `json:"inlined" inline:"true"`
+		Other   Item            `json:"other"`
+		List    []Item          `json:"list" inline:"true"`
+		Map     map[string]Item `json:"map" inline:"true"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestInner":{"properties":{"baz":{"type":"integer"}},"type":"object"},
+		"JsonschemaGoTestItem":{
+		  "properties":{
+			"foo":{"type":"string"},
+			"inner":{"$ref":"#/definitions/JsonschemaGoTestInner"}
+		  },
+		  "type":"object"
+		}
+	  },
+	  "properties":{
+		"inlined":{
+		  "properties":{
+			"foo":{"type":"string"},
+			"inner":{"$ref":"#/definitions/JsonschemaGoTestInner"}
+		  },
+		  "type":["object","null"]
+		},
+		"list":{
+		  "items":{
+			"properties":{
+			  "foo":{"type":"string"},
+			  "inner":{"$ref":"#/definitions/JsonschemaGoTestInner"}
+			},
+			"type":"object"
+		  },
+		  "type":["array","null"]
+		},
+		"map":{
+		  "additionalProperties":{
+			"properties":{
+			  "foo":{"type":"string"},
+			  "inner":{"$ref":"#/definitions/JsonschemaGoTestInner"}
+			},
+			"type":"object"
+		  },
+		  "type":["object","null"]
+		},
+		"other":{"$ref":"#/definitions/JsonschemaGoTestItem"},
+		"ref":{"$ref":"#/definitions/JsonschemaGoTestItem"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+type inlinedNode struct {
+	Value int          `json:"value"`
+	Next  *inlinedNode `json:"next" inline:"true"`
+}
+
+func TestReflector_Reflect_inlineTagRecursive(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Node inlinedNode `json:"node" inline:"true"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestInlinedNode":{
+		  "properties":{
+			"next":{"$ref":"#/definitions/JsonschemaGoTestInlinedNode"},
+			"value":{"type":"integer"}
+		  },
+		  "type":"object"
+		}
+	  },
+	  "properties":{
+		"node":{
+		  "properties":{
+			"next":{"$ref":"#/definitions/JsonschemaGoTestInlinedNode"},
+			"value":{"type":"integer"}
+		  },
+		  "type":"object"
+		}
+	  },
+	  "type":"object"
+	}`, s)
+
+	s, err = r.Reflect(inlinedNode{})
+	require.NoError(t, err)
+	assert.Equal(t, "#", *s.Properties["next"].TypeObject.Properties["next"].TypeObject.Ref)
+}
+
+func TestReflector_Reflect_defNameTag(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type Item struct {
+		Foo string `json:"foo"`
+	}
+
+	type Other struct {
+		Bar int `json:"bar"`
+	}
+
+	type My struct {
+		Default Item             `json:"default"`
+		Custom  *Item            `json:"custom" defName:"CustomItem"`
+		Again   Item             `json:"again" defName:"CustomItem"`
+		Other   Other            `json:"other" defName:"CustomItem"`
+		List    []Other          `json:"list" defName:"ListedOther"`
+		Map     map[string]*Item `json:"map" defName:"MappedItem"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"CustomItem":{"properties":{"foo":{"type":"string"}},"type":"object"},
+		"CustomItemType2":{"properties":{"bar":{"type":"integer"}},"type":"object"},
+		"JsonschemaGoTestItem":{"properties":{"foo":{"type":"string"}},"type":"object"},
+		"ListedOther":{"properties":{"bar":{"type":"integer"}},"type":"object"},
+		"MappedItem":{"properties":{"foo":{"type":"string"}},"type":"object"}
+	  },
+	  "properties":{
+		"again":{"$ref":"#/definitions/CustomItem"},
+		"custom":{"$ref":"#/definitions/CustomItem"},
+		"default":{"$ref":"#/definitions/JsonschemaGoTestItem"},
+		"list":{"items":{"$ref":"#/definitions/ListedOther"},"type":["array","null"]},
+		"map":{"additionalProperties":{"$ref":"#/definitions/MappedItem"},"type":["object","null"]},
+		"other":{"$ref":"#/definitions/CustomItemType2"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestReflector_Reflect_exclusiveBoundsDraft04(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Foo int     `json:"foo" exclusiveMinimum:"1" exclusiveMaximum:"10"`
+		Bar float64 `json:"bar" minimum:"1" exclusiveMaximum:"10.5"`
+	}
+
+	s, err := r.Reflect(My{}, jsonschema.ExclusiveBoundsDraft04)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"bar":{"maximum":10.5,"minimum":1,"exclusiveMaximum":true,"type":"number"},
+		"foo":{
+		  "maximum":10,"minimum":1,"exclusiveMaximum":true,"exclusiveMinimum":true,
+		  "type":"integer"
+		}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestReflector_Reflect_bigNumbers(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Int   int64   `json:"int" minimum:"-9223372036854775808" maximum:"9223372036854775807" default:"9223372036854775807"`
+		Uint  uint64  `json:"uint" maximum:"18446744073709551615" const:"18446744073709551615"`
+		Float float64 `json:"float" minimum:"0.1" default:"1.00000000000000000001"`
+	}
+
+	s, err := r.Reflect(My{}, jsonschema.BigNumbers)
+	require.NoError(t, err)
+
+	j, err := json.Marshal(s)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"properties":{`+
+		`"float":{"default":1.00000000000000000001,"minimum":0.1,"type":"number"},`+
+		`"int":{"default":9223372036854775807,"type":"integer",`+
+		`"maximum":9223372036854775807,"minimum":-9223372036854775808},`+
+		`"uint":{"minimum":0,"const":18446744073709551615,"type":"integer","maximum":18446744073709551615}`+
+		`},"type":"object"}`, string(j))
+
+	// Typed bounds are kept for schema consumers.
+	require.NotNil(t, s.Properties["uint"].TypeObject.Maximum)
+	assert.Equal(t, 1.8446744073709552e19, *s.Properties["uint"].TypeObject.Maximum)
+	assert.Error(t, s.ValidateJSON([]byte(`{"uint":1e20}`)))
+
+	s, err = r.Reflect(My{})
+	require.NoError(t, err)
+
+	j, err = json.Marshal(s.Properties["uint"])
+	require.NoError(t, err)
+	assert.Equal(t, `{"maximum":18446744073709552000,"minimum":0,"const":18446744073709552000,"type":"integer"}`, string(j))
+}
+
+func TestReflector_Reflect_deprecatedMsg(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Foo string `json:"foo" deprecated:"true" deprecatedMsg:"Use fooV2 instead."`
+		Bar string `json:"bar" deprecated:"true"`
+		Baz string `json:"baz" deprecatedMsg:"Ignored without deprecated."`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"bar":{"type":"string","deprecated":true},"baz":{"type":"string"},
+		"foo":{"type":"string","deprecated":true,"x-deprecated-reason":"Use fooV2 instead."}
+	  },
+	  "type":"object"
+	}`, s)
+
+	s, err = r.Reflect(My{}, jsonschema.DeprecatedReasonProperty("x-deprecation"))
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{"type":"string","deprecated":true,"x-deprecation":"Use fooV2 instead."}`,
+		s.Properties["foo"])
+}
+
 func TestReflector_Reflect_customTime(t *testing.T) {
 	type MyTime time.Time
 
@@ -2031,6 +2340,52 @@ func (r rawExposer) JSONSchemaBytes() ([]byte, error) {
 	return r()
 }
 
+type roleObject struct {
+	Level string `json:"level"`
+}
+
+func (r roleObject) MarshalText() ([]byte, error) {
+	return []byte(r.Level), nil
+}
+
+func (r *roleObject) UnmarshalText(data []byte) error {
+	r.Level = string(data)
+
+	return nil
+}
+
+func (roleObject) IgnoreTextMarshaler() {}
+
+func TestReflector_Reflect_ignoreTextMarshaler(t *testing.T) {
+	type T struct {
+		Role       Role       `json:"role"`
+		RoleObject roleObject `json:"roleObject"`
+		ID         UUID       `json:"id"`
+	}
+
+	reflector := jsonschema.Reflector{}
+	reflector.SkipTextMarshaler(UUID{})
+
+	schema, err := reflector.Reflect(T{})
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestRoleObject":{"properties":{"level":{"type":"string"}},"type":"object"},
+		"JsonschemaGoTestUUID":{
+		  "items":{"minimum":0,"type":"integer"},"maxItems":16,"minItems":16,
+		  "type":["array","null"]
+		}
+	  },
+	  "properties":{
+		"id":{"$ref":"#/definitions/JsonschemaGoTestUUID"},
+		"role":{"type":"string"},
+		"roleObject":{"$ref":"#/definitions/JsonschemaGoTestRoleObject"}
+	  },
+	  "type":"object"
+	}`, schema)
+}
+
 func TestReflector_AddOperation_rawSchema(t *testing.T) {
 	r := jsonschema.Reflector{}
 
@@ -2091,6 +2446,39 @@ func TestReflector_Reflect_ptrDefault(t *testing.T) {
 	}`, s)
 }
 
+func TestReflector_Reflect_listDefault(t *testing.T) {
+	type S struct {
+		A []*int `json:"a" default:"[1,null]"`
+		B []int  `json:"b" default:"[1, 2]"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(S{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"a":{"default":[1,null],"items":{"type":["null","integer"]},"type":["array","null"]},
+		"b":{"default":[1,2],"items":{"type":"integer"},"type":["array","null"]}
+	  },
+	  "type":"object"
+	}`, s)
+
+	type Mistyped struct {
+		A []int `json:"a" default:"[1.5,\"x\"]"`
+	}
+
+	_, err = r.Reflect(Mistyped{})
+	assert.EqualError(t, err, "A: parsing default: item 1.5 is not a valid integer")
+
+	type MistypedString struct {
+		A []bool `json:"a" example:"[true,\"false\"]"`
+	}
+
+	_, err = r.Reflect(MistypedString{})
+	assert.EqualError(t, err, `A: parsing example: item "false" is not a valid boolean`)
+}
+
 func TestReflector_Reflect_nilPreparer(t *testing.T) {
 	var o *Org
 
@@ -2186,23 +2574,215 @@ func (w withValExposer) JSONSchema() (jsonschema.Schema, error) {
 	return s, nil
 }
 
-func TestReflector_Reflect_Exposer(t *testing.T) {
-	r := jsonschema.Reflector{}
+type auditInfo struct {
+	CreatedBy string `json:"createdBy"`
+}
 
-	var (
-		np *withPtrExposer
-		nv *withValExposer
-	)
+func (auditInfo) PrepareJSONSchemaProperty(field reflect.StructField, propertySchema, parent *jsonschema.Schema) error {
+	if field.Tag.Get("audit") == "readOnly" {
+		propertySchema.WithReadOnly(true)
+	}
 
-	s, err := r.Reflect(np)
-	require.NoError(t, err)
-	assertjson.EqMarshal(t, `{"title":""}`, s)
+	if parent.Title != nil {
+		propertySchema.WithDescription("Audit of " + *parent.Title + ".")
+	}
 
-	s, err = r.Reflect(nv)
-	require.NoError(t, err)
-	assertjson.EqMarshal(t, `{"title":""}`, s)
+	return nil
+}
 
-	s, err = r.Reflect(withValExposer("test1"))
+type auditedOrder struct {
+	Audit  auditInfo  `json:"audit" audit:"readOnly"`
+	Audit2 *auditInfo `json:"audit2"`
+}
+
+func (auditedOrder) Title() string {
+	return "Order"
+}
+
+func TestReflector_Reflect_PropertyPreparer(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(auditedOrder{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "title":"Order",
+	  "definitions":{
+		"JsonschemaGoTestAuditInfo":{"properties":{"createdBy":{"type":"string"}},"type":"object"}
+	  },
+	  "properties":{
+		"audit":{
+		  "$ref":"#/definitions/JsonschemaGoTestAuditInfo","description":"Audit of Order.",
+		  "readOnly":true
+		},
+		"audit2":{"$ref":"#/definitions/JsonschemaGoTestAuditInfo","description":"Audit of Order."}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+type requiredByRules struct {
+	ID    int    `json:"id" required:"true"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func (*requiredByRules) JSONSchemaRequired() []string {
+	return []string{"id", "email"}
+}
+
+func TestReflector_Reflect_RequiredExposer(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(requiredByRules{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "required":["id","email"],
+	  "properties":{"email":{"type":"string"},"id":{"type":"integer"},"name":{"type":"string"}},
+	  "type":"object"
+	}`, s)
+}
+
+type withExtras struct {
+	Foo string `json:"foo"`
+}
+
+func (withExtras) JSONSchemaExtras() map[string]interface{} {
+	return map[string]interface{}{
+		"x-go-type":  "withExtras",
+		"x-internal": true,
+	}
+}
+
+type exposedWithExtras struct{}
+
+func (exposedWithExtras) JSONSchema() (jsonschema.Schema, error) {
+	s := jsonschema.Schema{}
+	s.AddType(jsonschema.Integer)
+
+	return s, nil
+}
+
+func (exposedWithExtras) JSONSchemaExtras() map[string]interface{} {
+	return map[string]interface{}{"x-go-type": "exposedWithExtras"}
+}
+
+func TestReflector_Reflect_ExtrasExposer(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Extras  withExtras        `json:"extras"`
+		Exposed exposedWithExtras `json:"exposed"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestWithExtras":{
+		  "properties":{"foo":{"type":"string"}},"type":"object","x-go-type":"withExtras",
+		  "x-internal":true
+		}
+	  },
+	  "properties":{
+		"exposed":{"type":"integer","x-go-type":"exposedWithExtras"},
+		"extras":{"$ref":"#/definitions/JsonschemaGoTestWithExtras"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestReflector_AddPreparer(t *testing.T) {
+	r := jsonschema.Reflector{}
+	r.AddPreparer(time.Duration(0), func(schema *jsonschema.Schema) error {
+		schema.WithDescription("Duration in nanoseconds.")
+
+		return nil
+	})
+	r.AddPreparer(time.Time{}, func(schema *jsonschema.Schema) error {
+		schema.WithExamples("2006-01-02T15:04:05Z")
+
+		return nil
+	})
+	r.AddPreparer(Role{}, func(schema *jsonschema.Schema) error {
+		return errors.New("failed")
+	})
+
+	type My struct {
+		Timeout time.Duration `json:"timeout"`
+		Time    *time.Time    `json:"time"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"time":{"examples":["2006-01-02T15:04:05Z"],"type":["null","string"],"format":"date-time"},
+		"timeout":{"description":"Duration in nanoseconds.","type":"integer"}
+	  },
+	  "type":"object"
+	}`, s)
+
+	_, err = r.Reflect(Role{})
+	assert.EqualError(t, err, "failed")
+}
+
+func TestReflector_AddFieldOverride(t *testing.T) {
+	r := jsonschema.Reflector{}
+	r.AddFieldOverride(Entity{}, "Meta", *(&jsonschema.Schema{}).
+		WithDescription("Arbitrary metadata.").
+		WithType(jsonschema.Object.Type()).
+		WithExtraPropertiesItem("x-meta", true))
+	r.AddFieldOverride(Entity{}, "CreatedAt", *(&jsonschema.Schema{}).WithReadOnly(true))
+
+	s, err := r.Reflect(Entity{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"birthDate":{"type":"string","format":"date"},
+		"createdAt":{"readOnly":true,"type":"string","format":"date-time"},
+		"deathDate":{"type":["null","string"],"format":"date"},
+		"deletedAt":{"type":["null","string"],"format":"date-time"},
+		"meta":{"description":"Arbitrary metadata.","type":"object","x-meta":true}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestReflector_AddFieldOverride_dialects(t *testing.T) {
+	type My struct {
+		Name string `json:"name"`
+	}
+
+	r := jsonschema.Reflector{}
+	r.AddFieldOverride(My{}, "Name", *(&jsonschema.Schema{}).
+		WithType(jsonschema.Type{SliceOfSimpleTypeValues: []jsonschema.SimpleType{jsonschema.String, jsonschema.Null}}))
+
+	s, err := r.Reflect(My{}, jsonschema.OpenAPI30)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{"properties":{"name":{"type":"string","nullable":true}},"type":"object"}`, s)
+
+	s, err = r.Reflect(My{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{"properties":{"name":{"type":["string","null"]}},"type":"object"}`, s)
+}
+
+func TestReflector_Reflect_Exposer(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	var (
+		np *withPtrExposer
+		nv *withValExposer
+	)
+
+	s, err := r.Reflect(np)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{"title":""}`, s)
+
+	s, err = r.Reflect(nv)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{"title":""}`, s)
+
+	s, err = r.Reflect(withValExposer("test1"))
 	require.NoError(t, err)
 	assertjson.EqMarshal(t, `{"title":"test1"}`, s)
 
@@ -2223,6 +2803,98 @@ func (w withValRawExposer) JSONSchemaBytes() ([]byte, error) {
 	return []byte(`{"title":"` + string(w) + `"}`), nil
 }
 
+type pagedList struct{}
+
+func (pagedList) JSONSchemaWith(reflect jsonschema.ReflectFunc) (jsonschema.Schema, error) {
+	item, err := reflect(circle{})
+	if err != nil {
+		return jsonschema.Schema{}, err
+	}
+
+	s := jsonschema.Schema{}
+	s.AddType(jsonschema.Object)
+	s.WithPropertiesItem("items", (&jsonschema.Schema{}).
+		WithType(jsonschema.Array.Type()).
+		WithItems(jsonschema.Items{SchemaOrBool: &jsonschema.SchemaOrBool{TypeObject: &item}}).
+		ToSchemaOrBool())
+	s.WithPropertiesItem("total", jsonschema.Integer.ToSchemaOrBool())
+
+	return s, nil
+}
+
+func TestReflector_Reflect_ReflectingExposer(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Circle circle    `json:"circle"`
+		List   pagedList `json:"list"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestCircle":{
+		  "properties":{"kind":{"const":"circle","type":"string"},"radius":{"type":"number"}},
+		  "type":"object"
+		},
+		"JsonschemaGoTestPagedList":{
+		  "properties":{
+			"items":{"items":{"$ref":"#/definitions/JsonschemaGoTestCircle"},"type":"array"},
+			"total":{"type":"integer"}
+		  },
+		  "type":"object"
+		}
+	  },
+	  "properties":{
+		"circle":{"$ref":"#/definitions/JsonschemaGoTestCircle"},
+		"list":{"$ref":"#/definitions/JsonschemaGoTestPagedList"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+type withSchemaFile struct {
+	fsys fstest.MapFS
+}
+
+func (w withSchemaFile) JSONSchemaFile() (fs.FS, string) {
+	return w.fsys, "schema.json"
+}
+
+func TestReflector_Reflect_SchemaFileExposer(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	fsys := fstest.MapFS{
+		"schema.json": &fstest.MapFile{Data: []byte(`{"type":"object","properties":{"foo":{"type":"string"}}}`)},
+	}
+
+	type My struct {
+		File withSchemaFile `json:"file"`
+	}
+
+	s, err := r.Reflect(My{File: withSchemaFile{fsys: fsys}})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestWithSchemaFile":{"properties":{"foo":{"type":"string"}},"type":"object"}
+	  },
+	  "properties":{"file":{"$ref":"#/definitions/JsonschemaGoTestWithSchemaFile"}},
+	  "type":"object"
+	}`, s)
+
+	// Schema is cached, file is not read again.
+	delete(fsys, "schema.json")
+
+	s, err = r.Reflect(withSchemaFile{fsys: fsys})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{"properties":{"foo":{"type":"string"}},"type":"object"}`, s)
+
+	_, err = (&jsonschema.Reflector{}).Reflect(withSchemaFile{fsys: fsys})
+	assert.EqualError(t, err, "reading schema file of jsonschema_test.withSchemaFile: "+
+		"open schema.json: file does not exist")
+}
+
 func TestReflector_Reflect_RawExposer(t *testing.T) {
 	r := jsonschema.Reflector{}
 
@@ -2297,6 +2969,147 @@ func (w withValNamedEnum) NamedEnum() ([]interface{}, []string) {
 	return []interface{}{string(w)}, []string{"n:" + string(w)}
 }
 
+func TestReflector_Reflect_enumTagTyped(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Int   int     `json:"int" enum:"1,2,3"`
+		Float float64 `json:"float" enum:"1.5, 2.5"`
+		Bool  bool    `json:"bool" enum:"true,false"`
+		Str   string  `json:"str" enum:"1,2"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"bool":{"enum":[true,false],"type":"boolean"},
+		"float":{"enum":[1.5,2.5],"type":"number"},
+		"int":{"enum":[1,2,3],"type":"integer"},
+		"str":{"enum":["1","2"],"type":"string"}
+	  },
+	  "type":"object"
+	}`, s)
+
+	type Bad struct {
+		Nested struct {
+			BadInt int `json:"badInt" enum:"1,two"`
+		} `json:"nested"`
+	}
+
+	_, err = r.Reflect(Bad{})
+	assert.EqualError(t, err, `nested.badInt: parsing enum value "two" as integer: `+
+		`strconv.ParseInt: parsing "two": invalid syntax`)
+}
+
+type eventType string
+
+func (eventType) JSONSchemaConst() interface{} {
+	return "user.created"
+}
+
+type ptrConst int
+
+func (*ptrConst) JSONSchemaConst() interface{} {
+	return 42
+}
+
+type exposedConst string
+
+func (exposedConst) JSONSchema() (jsonschema.Schema, error) {
+	s := jsonschema.Schema{}
+	s.AddType(jsonschema.String)
+	s.WithDescription("Exposed.")
+
+	return s, nil
+}
+
+func (exposedConst) JSONSchemaConst() interface{} {
+	return "exposed"
+}
+
+func TestReflector_Reflect_ConstExposer(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type Event struct {
+		Type    eventType    `json:"type"`
+		Value   ptrConst     `json:"value"`
+		Exposed exposedConst `json:"exposed"`
+	}
+
+	s, err := r.Reflect(Event{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestEventType":{"const":"user.created","type":"string"},
+		"JsonschemaGoTestExposedConst":{"description":"Exposed.","const":"exposed","type":"string"},
+		"JsonschemaGoTestPtrConst":{"const":42,"type":"integer"}
+	  },
+	  "properties":{
+		"exposed":{"$ref":"#/definitions/JsonschemaGoTestExposedConst"},
+		"type":{"$ref":"#/definitions/JsonschemaGoTestEventType"},
+		"value":{"$ref":"#/definitions/JsonschemaGoTestPtrConst"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+type oldModel struct {
+	Foo string `json:"foo"`
+}
+
+func (oldModel) JSONSchemaDeprecated() bool {
+	return true
+}
+
+type currentModel struct {
+	Bar string `json:"bar"`
+}
+
+func (*currentModel) JSONSchemaDeprecated() bool {
+	return false
+}
+
+type exposedOldModel struct{}
+
+func (exposedOldModel) JSONSchemaBytes() ([]byte, error) {
+	return []byte(`{"type":"string"}`), nil
+}
+
+func (exposedOldModel) JSONSchemaDeprecated() bool {
+	return true
+}
+
+func TestReflector_Reflect_Deprecated(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Old     oldModel        `json:"old"`
+		OldPtr  *oldModel       `json:"oldPtr"`
+		Current *currentModel   `json:"current"`
+		Exposed exposedOldModel `json:"exposed"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestCurrentModel":{"properties":{"bar":{"type":"string"}},"type":"object"},
+		"JsonschemaGoTestOldModel":{
+		  "properties":{"foo":{"type":"string"}},"type":"object","deprecated":true
+		}
+	  },
+	  "properties":{
+		"current":{"$ref":"#/definitions/JsonschemaGoTestCurrentModel"},
+		"exposed":{"type":"string","deprecated":true},
+		"old":{"$ref":"#/definitions/JsonschemaGoTestOldModel"},
+		"oldPtr":{"$ref":"#/definitions/JsonschemaGoTestOldModel"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
 func TestReflector_Reflect_NamedEnum(t *testing.T) {
 	r := jsonschema.Reflector{}
 
@@ -2334,10 +3147,55 @@ func (w withValOneOfExposer) JSONSchemaOneOf() []interface{} {
 	return []interface{}{withValPreparer(w), withPtrPreparer("2:" + w)}
 }
 
-func TestReflector_Reflect_OneOfExposer(t *testing.T) {
+type withDescribedEnum string
+
+func (withDescribedEnum) DescribedEnum() ([]interface{}, []string, []string) {
+	return []interface{}{"p", "a"},
+		[]string{"Pending", "Active"},
+		[]string{"Waiting for approval.", "Approved and running."}
+}
+
+func TestReflector_Reflect_DescribedEnum(t *testing.T) {
 	r := jsonschema.Reflector{}
 
-	var (
+	s, err := r.Reflect(withDescribedEnum(""))
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "enum":["p","a"],"type":"string","x-enum-names":["Pending","Active"],
+	  "x-enum-descriptions":["Waiting for approval.","Approved and running."]
+	}`, s)
+}
+
+func TestReflector_AddEnum(t *testing.T) {
+	r := jsonschema.Reflector{}
+	r.AddEnum(time.Weekday(0), 1, 2, 3, 4, 5)
+	r.AddNamedEnum(time.Month(0), []interface{}{1, 2}, []string{"January", "February"})
+
+	type My struct {
+		Day      time.Weekday  `json:"day"`
+		Month    time.Month    `json:"month"`
+		Duration time.Duration `json:"duration"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"TimeMonth":{"enum":[1,2],"type":"integer","x-enum-names":["January","February"]},
+		"TimeWeekday":{"enum":[1,2,3,4,5],"type":"integer"}
+	  },
+	  "properties":{
+		"day":{"$ref":"#/definitions/TimeWeekday"},"duration":{"type":"integer"},
+		"month":{"$ref":"#/definitions/TimeMonth"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestReflector_Reflect_OneOfExposer(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	var (
 		np *withPtrOneOfExposer
 		nv *withValOneOfExposer
 	)
@@ -2383,6 +3241,139 @@ func (w withValAnyOfExposer) JSONSchemaAnyOf() []interface{} {
 	return []interface{}{withValPreparer(w), withPtrPreparer("2:" + w)}
 }
 
+type (
+	circle struct {
+		Kind   string  `json:"kind" const:"circle"`
+		Radius float64 `json:"radius"`
+	}
+	square struct {
+		Kind string  `json:"kind" const:"square"`
+		Side float64 `json:"side"`
+	}
+	shape        struct{}
+	shapeOfOneOf struct{}
+)
+
+func (shape) JSONSchemaDiscriminator() (string, map[string]interface{}) {
+	return "kind", map[string]interface{}{
+		"circle": circle{},
+		"square": square{},
+	}
+}
+
+func (shapeOfOneOf) JSONSchemaDiscriminator() (string, map[string]interface{}) {
+	return "kind", map[string]interface{}{
+		"circle": circle{},
+	}
+}
+
+func (shapeOfOneOf) JSONSchemaOneOf() []interface{} {
+	return []interface{}{circle{}, square{}}
+}
+
+func TestReflector_Reflect_DiscriminatedOneOfExposer(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Shape  shape        `json:"shape"`
+		Shape2 shapeOfOneOf `json:"shape2"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestCircle":{
+		  "properties":{"kind":{"const":"circle","type":"string"},"radius":{"type":"number"}},
+		  "type":"object"
+		},
+		"JsonschemaGoTestShape":{
+		  "type":"object",
+		  "oneOf":[
+			{"$ref":"#/definitions/JsonschemaGoTestCircle"},
+			{"$ref":"#/definitions/JsonschemaGoTestSquare"}
+		  ],
+		  "discriminator":{
+			"propertyName":"kind",
+			"mapping":{
+			  "circle":"#/definitions/JsonschemaGoTestCircle",
+			  "square":"#/definitions/JsonschemaGoTestSquare"
+			}
+		  }
+		},
+		"JsonschemaGoTestShapeOfOneOf":{
+		  "type":"object",
+		  "oneOf":[
+			{"$ref":"#/definitions/JsonschemaGoTestCircle"},
+			{"$ref":"#/definitions/JsonschemaGoTestSquare"}
+		  ],
+		  "discriminator":{
+			"propertyName":"kind",
+			"mapping":{"circle":"#/definitions/JsonschemaGoTestCircle"}
+		  }
+		},
+		"JsonschemaGoTestSquare":{
+		  "properties":{"kind":{"const":"square","type":"string"},"side":{"type":"number"}},
+		  "type":"object"
+		}
+	  },
+	  "properties":{
+		"shape":{"$ref":"#/definitions/JsonschemaGoTestShape"},
+		"shape2":{"$ref":"#/definitions/JsonschemaGoTestShapeOfOneOf"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+type (
+	shapeIface interface {
+		Area() float64
+	}
+	circleImpl struct {
+		Radius float64 `json:"radius"`
+	}
+	squareImpl struct {
+		Side float64 `json:"side"`
+	}
+)
+
+func (c circleImpl) Area() float64 { return c.Radius * c.Radius * 3.14 }
+func (s squareImpl) Area() float64 { return s.Side * s.Side }
+
+func TestReflector_AddInterfaceImpls(t *testing.T) {
+	r := jsonschema.Reflector{}
+	r.AddInterfaceImpls(new(shapeIface), circleImpl{}, squareImpl{})
+
+	type My struct {
+		Shape  shapeIface   `json:"shape"`
+		Shapes []shapeIface `json:"shapes"`
+		Any    interface{}  `json:"any"`
+	}
+
+	s, err := r.Reflect(My{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestCircleImpl":{"properties":{"radius":{"type":"number"}},"type":"object"},
+		"JsonschemaGoTestShapeIface":{
+		  "oneOf":[
+			{"$ref":"#/definitions/JsonschemaGoTestCircleImpl"},
+			{"$ref":"#/definitions/JsonschemaGoTestSquareImpl"}
+		  ]
+		},
+		"JsonschemaGoTestSquareImpl":{"properties":{"side":{"type":"number"}},"type":"object"}
+	  },
+	  "properties":{
+		"any":{},"shape":{"$ref":"#/definitions/JsonschemaGoTestShapeIface"},
+		"shapes":{
+		  "items":{"$ref":"#/definitions/JsonschemaGoTestShapeIface"},
+		  "type":["array","null"]
+		}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
 func TestReflector_Reflect_AnyOfExposer(t *testing.T) {
 	r := jsonschema.Reflector{}
 
@@ -2617,6 +3608,142 @@ func TestReflector_Reflect_ElseExposer(t *testing.T) {
 	assertjson.EqMarshal(t, `{"type":"string","else":{"title":"test2","type":"string"}}`, s)
 }
 
+type withDependentSchemas struct {
+	Name       string `json:"name"`
+	CreditCard int    `json:"creditCard,omitempty"`
+}
+
+func (withDependentSchemas) JSONSchemaDependentSchemas() map[string]interface{} {
+	return map[string]interface{}{
+		"creditCard": struct {
+			BillingAddress string `json:"billingAddress" required:"true"`
+		}{},
+	}
+}
+
+func TestReflector_Reflect_DependentSchemasExposer(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(withDependentSchemas{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{"creditCard":{"type":"integer"},"name":{"type":"string"}},
+	  "dependencies":{
+		"creditCard":{
+		  "required":["billingAddress"],
+		  "properties":{"billingAddress":{"type":"string"}},"type":"object"
+		}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+type withPatternProperties map[string]interface{}
+
+func (withPatternProperties) JSONSchemaPatternProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"^S_": "",
+		"^I_": 0,
+		"^P_": Person{},
+	}
+}
+
+func TestReflector_Reflect_PatternPropertiesExposer(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type My struct {
+		Values withPatternProperties `json:"values"`
+	}
+
+	s, err := r.Reflect(My{}, jsonschema.InlineRefs)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"values":{
+		  "additionalProperties":{},"type":["object","null"],
+		  "patternProperties":{
+			"^I_":{"type":"integer"},
+			"^P_":{
+			  "title":"Person","required":["lastName"],
+			  "properties":{
+				"birthDate":{"type":"string","format":"date"},
+				"createdAt":{"type":"string","format":"date-time"},
+				"date":{"type":"string","format":"date"},
+				"deathDate":{"type":["null","string"],"format":"date"},
+				"deletedAt":{"type":["null","string"],"format":"date-time"},
+				"enumed":{"enum":["foo","bar"],"type":"string"},
+				"enumedPtr":{"enum":["foo","bar"],"type":["null","string"]},
+				"firstName":{"type":"string"},"height":{"type":"integer"},
+				"lastName":{"type":"string"},"meta":{},
+				"role":{"description":"The role of person.","type":"string"}
+			  },
+			  "type":"object"
+			},
+			"^S_":{"type":"string"}
+		  }
+		}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+type withConditional struct {
+	Country    string `json:"country"`
+	PostalCode string `json:"postalCode"`
+}
+
+func (withConditional) JSONSchemaIfThenElse() (ifv, thenv, elsev interface{}) {
+	return struct {
+		Country string `json:"country" const:"US"`
+	}{}, struct {
+		PostalCode string `json:"postalCode" pattern:"^[0-9]{5}$"`
+	}{}, nil
+}
+
+func TestReflector_Reflect_ConditionalExposer(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(withConditional{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{"country":{"type":"string"},"postalCode":{"type":"string"}},
+	  "type":"object",
+	  "if":{"properties":{"country":{"const":"US","type":"string"}},"type":"object"},
+	  "then":{
+		"properties":{"postalCode":{"pattern":"^[0-9]{5}$","type":"string"}},
+		"type":"object"
+	  }
+	}`, s)
+}
+
+type failingThen struct{}
+
+func (failingThen) JSONSchemaThen() interface{} {
+	return struct {
+		N int `json:"n" enum:"one"`
+	}{}
+}
+
+type failingElse struct{}
+
+func (failingElse) JSONSchemaElse() interface{} {
+	return struct {
+		N int `json:"n" enum:"one"`
+	}{}
+}
+
+func TestReflector_Reflect_thenElsePath(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	_, err := r.Reflect(failingThen{})
+	assert.EqualError(t, err, `failed to reflect 'then' value of jsonschema_test.failingThen: `+
+		`then.n: parsing enum value "one" as integer: strconv.ParseInt: parsing "one": invalid syntax`)
+
+	_, err = r.Reflect(failingElse{})
+	assert.EqualError(t, err, `failed to reflect 'else' value of jsonschema_test.failingElse: `+
+		`else.n: parsing enum value "one" as integer: strconv.ParseInt: parsing "one": invalid syntax`)
+}
+
 func TestReflector_Reflect_byteSlice(t *testing.T) {
 	r := jsonschema.Reflector{}
 
@@ -2655,3 +3782,557 @@ func TestReflector_Reflect_byteSlice(t *testing.T) {
 	  "type":"object"
 	}`, s)
 }
+
+type tuplePoint struct {
+	Lat   float64 `minimum:"-90" maximum:"90"`
+	Lon   float64 `minimum:"-180" maximum:"180"`
+	Label string  `json:"-"`
+	alt   float64
+}
+
+func (tuplePoint) TupleStruct() {}
+
+func TestReflector_Reflect_tuple(t *testing.T) {
+	type T struct {
+		RGB   [3]uint8     `json:"rgb"`
+		Point tuplePoint   `json:"point"`
+		Path  []tuplePoint `json:"path,omitempty"`
+	}
+
+	reflector := jsonschema.Reflector{}
+
+	schema, err := reflector.Reflect(T{})
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestTuplePoint":{
+		  "additionalItems":false,
+		  "items":[
+			{"maximum":90,"minimum":-90,"type":"number"},
+			{"maximum":180,"minimum":-180,"type":"number"}
+		  ],
+		  "maxItems":2,"minItems":2,"type":"array"
+		}
+	  },
+	  "properties":{
+		"path":{"items":{"$ref":"#/definitions/JsonschemaGoTestTuplePoint"},"type":"array"},
+		"point":{"$ref":"#/definitions/JsonschemaGoTestTuplePoint"},
+		"rgb":{
+		  "items":{"minimum":0,"type":"integer"},"maxItems":3,"minItems":3,
+		  "type":["array","null"]
+		}
+	  },
+	  "type":"object"
+	}`, schema)
+
+	schema, err = reflector.Reflect(tuplePoint{}, jsonschema.Draft202012)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "items":false,"maxItems":2,"minItems":2,"type":"array",
+	  "prefixItems":[
+		{"maximum":90,"minimum":-90,"type":"number"},
+		{"maximum":180,"minimum":-180,"type":"number"}
+	  ]
+	}`, schema)
+}
+
+func TestForbidUnevaluatedProperties(t *testing.T) {
+	reflector := jsonschema.Reflector{}
+
+	schema, err := reflector.Reflect(Draft2020Order{}, jsonschema.ForbidUnevaluatedProperties)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestDraft2020Base":{"properties":{"id":{"type":"integer"}},"type":"object"}
+	  },
+	  "properties":{
+		"items":{"items":{"$ref":"#/definitions/JsonschemaGoTestDraft2020Base"},"type":["array","null"]}
+	  },
+	  "type":"object","allOf":[{"$ref":"#/definitions/JsonschemaGoTestDraft2020Base"}],
+	  "unevaluatedProperties":false
+	}`, schema)
+
+	var s jsonschema.Schema
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`{"unevaluatedProperties":{"type":"string"}}`)))
+	require.NotNil(t, s.UnevaluatedProperties)
+	assert.Empty(t, s.ExtraProperties)
+	assertjson.EqMarshal(t, `{"unevaluatedProperties":{"type":"string"}}`, s)
+}
+
+func TestReflector_Reflect_whenTag(t *testing.T) {
+	type Payment struct {
+		Type    string `json:"type" enum:"card,bank,cash" required:"true"`
+		Card    string `json:"card" when:"type=card"`
+		CVC     string `json:"cvc" when:"type=card"`
+		IBAN    string `json:"iban" when:"type=bank"`
+		Version int    `json:"version"`
+		Legacy  string `json:"legacy" when:"version=1"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(Payment{})
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "required":["type"],
+	  "properties":{
+		"card":{"type":"string"},"cvc":{"type":"string"},"iban":{"type":"string"},
+		"legacy":{"type":"string"},"type":{"enum":["card","bank","cash"],"type":"string"},
+		"version":{"type":"integer"}
+	  },
+	  "type":"object",
+	  "if":{"required":["type"],"properties":{"type":{"const":"card"}}},
+	  "then":{"required":["card","cvc"]},
+	  "else":{
+		"if":{"required":["type"],"properties":{"type":{"const":"bank"}}},
+		"then":{"required":["iban"]}
+	  },
+	  "allOf":[
+		{"if":{"required":["version"],"properties":{"version":{"const":1}}},"then":{"required":["legacy"]}}
+	  ]
+	}`, s)
+
+	require.NoError(t, s.ValidateJSON([]byte(`{"type":"card","card":"4242","cvc":"123"}`)))
+	require.NoError(t, s.ValidateJSON([]byte(`{"type":"cash"}`)))
+	assert.Error(t, s.ValidateJSON([]byte(`{"type":"bank"}`)))
+	assert.Error(t, s.ValidateJSON([]byte(`{"type":"cash","version":1}`)))
+
+	type Invalid struct {
+		Foo string `json:"foo" when:"bar"`
+	}
+
+	_, err = r.Reflect(Invalid{})
+	assert.EqualError(t, err, `foo: malformed when tag "bar", property=value expected`)
+
+	type Unknown struct {
+		Foo string `json:"foo" when:"bar=baz"`
+	}
+
+	_, err = r.Reflect(Unknown{})
+	assert.EqualError(t, err, `foo: unknown when property bar`)
+}
+
+func BenchmarkReflector_Reflect(b *testing.B) {
+	type Item struct {
+		Name   string            `json:"name" required:"true" minLength:"1"`
+		Price  float64           `json:"price" minimum:"0"`
+		Tags   []string          `json:"tags"`
+		Labels map[string]string `json:"labels"`
+	}
+
+	type Order struct {
+		ID        int       `json:"id"`
+		Items     []Item    `json:"items"`
+		Main      *Item     `json:"main"`
+		CreatedAt time.Time `json:"createdAt"`
+		Note      string    `json:"note" maxLength:"100"`
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		r := jsonschema.Reflector{}
+
+		if _, err := r.Reflect(Order{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSkipElementSamples(t *testing.T) {
+	type S struct {
+		List []interface{}          `json:"list"`
+		Map  map[string]interface{} `json:"map"`
+	}
+
+	r := jsonschema.Reflector{}
+	v := S{List: []interface{}{1}, Map: map[string]interface{}{"a": "b"}}
+
+	s, err := r.Reflect(v)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"list":{"items":{"type":"integer"},"type":["array","null"]},
+		"map":{"additionalProperties":{"type":"string"},"type":["object","null"]}
+	  },
+	  "type":"object"
+	}`, s)
+
+	s, err = r.Reflect(v, jsonschema.SkipElementSamples)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"list":{"items":{},"type":["array","null"]},
+		"map":{"additionalProperties":{},"type":["object","null"]}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestReflector_Reflect_unreachableDefinitions(t *testing.T) {
+	type Item struct {
+		Name string `json:"name"`
+	}
+
+	type Order struct {
+		Item  Item   `json:"item"`
+		Items []Item `json:"items"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	replaceItem := jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		if params.Processed && params.Name == "item" {
+			*params.PropertySchema = jsonschema.String.ToSchemaOrBool().TypeObject.Clone()
+		}
+
+		return nil
+	})
+
+	s, err := r.Reflect(Order{}, replaceItem)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"JsonschemaGoTestItem":{"properties":{"name":{"type":"string"}},"type":"object"}
+	  },
+	  "properties":{
+		"item":{"type":"string"},
+		"items":{"items":{"$ref":"#/definitions/JsonschemaGoTestItem"},"type":["array","null"]}
+	  },
+	  "type":"object"
+	}`, s)
+
+	s, err = r.Reflect(Order{}, replaceItem, jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		if params.Processed && params.Name == "items" {
+			*params.PropertySchema = jsonschema.Array.ToSchemaOrBool().TypeObject.Clone()
+		}
+
+		return nil
+	}))
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{"item":{"type":"string"},"items":{"type":"array"}},
+	  "type":"object"
+	}`, s)
+	assert.Empty(t, s.ReachableDefinitions())
+
+	var defs []string
+
+	_, err = r.Reflect(Order{}, replaceItem, jsonschema.CollectDefinitions(func(name string, _ jsonschema.Schema) {
+		defs = append(defs, name)
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"JsonschemaGoTestItem"}, defs)
+}
+
+func TestDurationAsString(t *testing.T) {
+	type S struct {
+		Timeout time.Duration  `json:"timeout"`
+		Delay   *time.Duration `json:"delay,omitempty"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(S{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{"delay":{"type":"integer"},"timeout":{"type":"integer"}},
+	  "type":"object"
+	}`, s)
+
+	s, err = r.Reflect(S{}, jsonschema.DurationAsString)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"delay":{"examples":["1h30m"],"type":["null","string"],"format":"duration"},
+		"timeout":{"examples":["1h30m"],"type":"string","format":"duration"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestReflector_Reflect_sqlNullTypes(t *testing.T) {
+	type S struct {
+		Name    sql.NullString  `json:"name"`
+		Count   sql.NullInt64   `json:"count"`
+		Small   sql.NullInt32   `json:"small"`
+		Price   sql.NullFloat64 `json:"price"`
+		Enabled sql.NullBool    `json:"enabled"`
+		At      *sql.NullTime   `json:"at"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(S{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"at":{"type":["null","string"],"format":"date-time"},
+		"count":{"type":["integer","null"]},"enabled":{"type":["boolean","null"]},
+		"name":{"type":["string","null"]},"price":{"type":["number","null"]},
+		"small":{"type":["integer","null"]}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestReflector_Reflect_netTypes(t *testing.T) {
+	type S struct {
+		IP       net.IP     `json:"ip"`
+		Addr     netip.Addr `json:"addr"`
+		Endpoint *url.URL   `json:"endpoint"`
+		Base     url.URL    `json:"base"`
+		Created  time.Time  `json:"created"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(S{}, jsonschema.InlineRefs)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"addr":{"anyOf":[{"format":"ipv4"},{"format":"ipv6"}],"type":"string"},
+		"base":{"format":"uri","type":"string"},
+		"created":{"format":"date-time","type":"string"},
+		"endpoint":{"format":"uri","type":["null","string"]},
+		"ip":{"anyOf":[{"format":"ipv4"},{"format":"ipv6"}],"type":"string"}
+	  },
+	  "type":"object"
+	}`, s)
+
+	r.SkipWellKnownTypes(net.IP{}, time.Time{})
+
+	s, err = r.Reflect(S{}, jsonschema.InlineRefs)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{"type":"string"}`, s.Properties["ip"])
+	assertjson.EqMarshal(t, `{"type":"string"}`, s.Properties["created"])
+}
+
+func TestReflector_AddWellKnownType(t *testing.T) {
+	type Token string
+
+	type S struct {
+		Data    []byte     `json:"data"`
+		Created *time.Time `json:"created"`
+		Token   Token      `json:"token"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	data := jsonschema.Schema{}
+	data.AddType(jsonschema.String)
+	data.WithContentEncoding("base64")
+
+	created := jsonschema.Schema{}
+	created.AddType(jsonschema.String)
+	created.WithFormat("date-time").WithExamples("2006-01-02T15:04:05Z")
+
+	token := jsonschema.Schema{}
+	token.AddType(jsonschema.String)
+	token.WithPattern("^[a-f0-9]{32}$")
+
+	r.AddWellKnownType([]byte{}, data)
+	r.AddWellKnownType(time.Time{}, created)
+	r.AddWellKnownType(Token(""), token)
+
+	s, err := r.Reflect(S{}, jsonschema.InlineRefs)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"created":{"examples":["2006-01-02T15:04:05Z"],"type":["string","null"],"format":"date-time"},
+		"data":{"type":"string","contentEncoding":"base64"},
+		"token":{"pattern":"^[a-f0-9]{32}$","type":"string"}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestReflector_Reflect_bigTypes(t *testing.T) {
+	type S struct {
+		Int   *big.Int   `json:"int"`
+		Float *big.Float `json:"float"`
+		Rat   *big.Rat   `json:"rat"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(S{}, jsonschema.InlineRefs)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"float":{"pattern":"^[-+]?([0-9]+(\\.[0-9]*)?|\\.[0-9]+)([eE][-+]?[0-9]+)?$","type":["null","string"]},
+		"int":{"type":["null","integer"]},
+		"rat":{"pattern":"^-?[0-9]+(/[0-9]+)?$","type":["null","string"]}
+	  },
+	  "type":"object"
+	}`, s)
+
+	// Schema accepts values marshaled by encoding/json.
+	assert.NoError(t, s.ValidateInterface(S{Int: big.NewInt(10), Float: big.NewFloat(1.5e10), Rat: big.NewRat(1, 3)}))
+
+	s, err = r.Reflect(S{}, jsonschema.InlineRefs, jsonschema.BigTypesAsStrings)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"float":{"pattern":"^[-+]?([0-9]+(\\.[0-9]*)?|\\.[0-9]+)([eE][-+]?[0-9]+)?$","type":["null","string"]},
+		"int":{"pattern":"^-?[0-9]+$","type":["null","string"]},
+		"rat":{"pattern":"^-?[0-9]+(/[0-9]+)?$","type":["null","string"]}
+	  },
+	  "type":"object"
+	}`, s)
+}
+
+func TestJSONNumberAs(t *testing.T) {
+	type S struct {
+		Amount json.Number  `json:"amount"`
+		Total  *json.Number `json:"total"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(S{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{"amount":{"type":"number"},"total":{"type":["null","number"]}},
+	  "type":"object"
+	}`, s)
+
+	s, err = r.Reflect(S{}, jsonschema.JSONNumberAs(jsonschema.Number, jsonschema.String))
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{"amount":{"type":["number","string"]},"total":{"type":["null","number","string"]}},
+	  "type":"object"
+	}`, s)
+}
+
+func TestReflector_Reflect_timeFormat(t *testing.T) {
+	type S struct {
+		Created  time.Time  `json:"created" timeFormat:"2006-01-02T15:04:05"`
+		Updated  *time.Time `json:"updated" timeFormat:"02 Jan 06 15:04:05.000 -0700"`
+		Day      time.Time  `json:"day" timeFormat:"2006-01-02"`
+		Stamp    time.Time  `json:"stamp" timeFormat:"2006-01-02T15:04:05.999999999Z07:00"`
+		Kitchen  string     `json:"kitchen" timeFormat:"3:04PM"`
+		Explicit time.Time  `json:"explicit" timeFormat:"15:04" format:"hh:mm"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(S{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"created":{"pattern":"^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}$","type":"string"},
+		"day":{"type":"string","format":"date"},
+		"explicit":{"pattern":"^[0-9]{2}:[0-9]{2}$","type":"string","format":"hh:mm"},
+		"kitchen":{"pattern":"^[0-9]{1,2}:[0-9]{2}(AM|PM)$","type":"string"},
+		"stamp":{"type":"string","format":"date-time"},
+		"updated":{
+		  "pattern":"^[0-9]{2} [A-Z][a-z]{2} [0-9]{2} [0-9]{2}:[0-9]{2}:[0-9]{2}\\.[0-9]{3} [+-][0-9]{4}$",
+		  "type":["null","string"]
+		}
+	  },
+	  "type":"object"
+	}`, s)
+
+	ts := time.Date(2023, 9, 8, 7, 6, 5, 4000000, time.FixedZone("", -3600))
+
+	for name, layout := range map[string]string{
+		"created":  "2006-01-02T15:04:05",
+		"updated":  "02 Jan 06 15:04:05.000 -0700",
+		"kitchen":  time.Kitchen,
+		"explicit": "15:04",
+	} {
+		assert.Regexp(t, *s.Properties[name].TypeObject.Pattern, ts.Format(layout), name)
+	}
+}
+
+func TestReflector_Reflect_binaryUploads(t *testing.T) {
+	type S struct {
+		File    *multipart.FileHeader   `json:"file"`
+		Files   []*multipart.FileHeader `json:"files"`
+		Upload  multipart.File          `json:"upload"`
+		Content io.ReadCloser           `json:"content"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(S{})
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "definitions":{
+		"IoReadCloser":{},
+		"MultipartFile":{"type":["null","string"],"format":"binary"},
+		"MultipartFileHeader":{"type":["null","string"],"format":"binary"}
+	  },
+	  "properties":{
+		"content":{"$ref":"#/definitions/IoReadCloser"},
+		"file":{"$ref":"#/definitions/MultipartFileHeader"},
+		"files":{"items":{"$ref":"#/definitions/MultipartFileHeader"},"type":["array","null"]},
+		"upload":{"$ref":"#/definitions/MultipartFile"}
+	  },
+	  "type":"object"
+	}`, s)
+
+	s, err = r.Reflect(S{}, jsonschema.ReaderAsBinary)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{"type":["null","string"],"format":"binary"}`, s.Definitions["IoReadCloser"])
+
+	r.SkipWellKnownTypes(multipart.FileHeader{})
+
+	s, err = r.Reflect(S{})
+	require.NoError(t, err)
+	assert.True(t, s.Definitions["MultipartFileHeader"].TypeObject.HasType(jsonschema.Object))
+}
+
+type nullString struct {
+	sql.NullString
+}
+
+func (n nullString) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(n.String)
+}
+
+type nullDate struct {
+	Date  time.Time
+	Valid bool
+}
+
+func (n nullDate) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(n.Date)
+}
+
+func TestInferNullableWrappers(t *testing.T) {
+	type S struct {
+		Name nullString `json:"name"`
+		Date *nullDate  `json:"date"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(S{}, jsonschema.InferNullableWrappers)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"date":{"type":["null","string"],"format":"date-time"},
+		"name":{"type":["null","string"]}
+	  },
+	  "type":"object"
+	}`, s)
+
+	s, err = r.Reflect(S{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"JsonschemaGoTestNullDate", "JsonschemaGoTestNullString"}, s.ReachableDefinitions())
+}
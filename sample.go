@@ -0,0 +1,429 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// formatSamples are sample values of well-known string formats.
+var formatSamples = map[string]string{
+	"date-time":     "2006-01-02T15:04:05Z",
+	"date":          "2006-01-02",
+	"time":          "15:04:05Z",
+	"duration":      "PT1H",
+	"email":         "user@example.com",
+	"idn-email":     "user@example.com",
+	"hostname":      "example.com",
+	"idn-hostname":  "example.com",
+	"ipv4":          "192.0.2.1",
+	"ipv6":          "2001:db8::1",
+	"uri":           "https://example.com/",
+	"iri":           "https://example.com/",
+	"uri-reference": "/path",
+	"iri-reference": "/path",
+	"uuid":          "248df4b7-aa70-47b8-a036-33ac447e668d",
+	"json-pointer":  "/foo",
+	"regex":         "^[a-z]+$",
+	"byte":          "U3dhZ2dlcg==",
+	"base64":        "U3dhZ2dlcg==",
+}
+
+// GenerateExample creates a sample value that is valid against the schema.
+//
+// Values of `const`, `default`, `examples` and `enum` are used if available, otherwise a value is made
+// from type, `format` and constraints (bounds, lengths, `multipleOf`, `required`, item counts).
+// Objects have all properties that can be generated, optional properties with cyclic references are omitted.
+// Local references are resolved against the schema.
+//
+// Error is returned if sample can not be generated (e.g. for `pattern` without matching candidate)
+// or if generated sample does not pass Schema.ValidateInterface.
+func GenerateExample(s Schema) (interface{}, error) {
+	g := sampler{root: &s, refs: map[string]bool{}}
+
+	v, err := g.sample("#", s)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ValidateInterface(v); err != nil {
+		return nil, fmt.Errorf("generated example is invalid: %w", err)
+	}
+
+	return v, nil
+}
+
+var errCyclicReference = errors.New("cyclic reference")
+
+type sampler struct {
+	root *Schema
+	refs map[string]bool
+}
+
+func (g *sampler) sampleSchemaOrBool(path string, sb *SchemaOrBool) (interface{}, error) {
+	if sb == nil || sb.TypeObject == nil {
+		if sb != nil && !*sb.TypeBoolean {
+			return nil, fmt.Errorf("%s: false schema does not allow values", path)
+		}
+
+		return nil, nil
+	}
+
+	return g.sample(path, *sb.TypeObject)
+}
+
+func (g *sampler) sample(path string, s Schema) (interface{}, error) {
+	if s.Ref != nil {
+		ref := *s.Ref
+
+		if g.refs[ref] {
+			return nil, fmt.Errorf("%s: %w %s", path, errCyclicReference, ref)
+		}
+
+		rs, found := g.root.ResolveRef(ref)
+		if !found {
+			return nil, fmt.Errorf("%s: unknown reference %s", path, ref)
+		}
+
+		g.refs[ref] = true
+		defer delete(g.refs, ref)
+
+		return g.sample(path, *rs)
+	}
+
+	switch {
+	case s.Const != nil:
+		return *s.Const, nil
+	case s.Default != nil:
+		return *s.Default, nil
+	case len(s.Examples) > 0:
+		return s.Examples[0], nil
+	case len(s.Enum) > 0:
+		for _, e := range s.Enum {
+			if e != nil {
+				return e, nil
+			}
+		}
+
+		return nil, nil
+	}
+
+	if len(s.AllOf) > 0 {
+		return g.sampleAllOf(path, s)
+	}
+
+	if len(s.AnyOf) > 0 {
+		return g.sampleAny(path+"/anyOf", s.AnyOf)
+	}
+
+	if len(s.OneOf) > 0 {
+		return g.sampleAny(path+"/oneOf", s.OneOf)
+	}
+
+	return g.sampleType(path, s)
+}
+
+// sampleAllOf merges `allOf` items into schema.
+func (g *sampler) sampleAllOf(path string, s Schema) (interface{}, error) {
+	merged := s.Clone()
+	merged.AllOf = nil
+
+	for i, item := range s.AllOf {
+		is := item.TypeObject
+		if is == nil {
+			continue
+		}
+
+		if is.Ref != nil {
+			rs, found := g.root.ResolveRef(*is.Ref)
+			if !found {
+				return nil, fmt.Errorf("%s/allOf/%d: unknown reference %s", path, i, *is.Ref)
+			}
+
+			is = rs
+		}
+
+		if err := Merge(&merged, is); err != nil {
+			return nil, fmt.Errorf("%s/allOf/%d: %w", path, i, err)
+		}
+	}
+
+	return g.sample(path, merged)
+}
+
+// sampleAny returns sample of the first item that can be generated, non-null items are preferred.
+func (g *sampler) sampleAny(path string, items []SchemaOrBool) (interface{}, error) {
+	var (
+		firstErr error
+		null     bool
+	)
+
+	for i := range items {
+		v, err := g.sampleSchemaOrBool(fmt.Sprintf("%s/%d", path, i), &items[i])
+
+		switch {
+		case err != nil && firstErr == nil:
+			firstErr = err
+		case err == nil && v == nil:
+			null = true
+		case err == nil:
+			return v, nil
+		}
+	}
+
+	if null {
+		return nil, nil
+	}
+
+	return nil, firstErr
+}
+
+func (g *sampler) sampleType(path string, s Schema) (interface{}, error) {
+	var t SimpleType
+
+	for _, st := range s.simpleTypes() {
+		if st != Null {
+			t = st
+
+			break
+		}
+	}
+
+	if t == "" {
+		switch {
+		case s.HasType(Null):
+			return nil, nil
+		case len(s.Properties) > 0 || len(s.Required) > 0:
+			t = Object
+		case s.Items != nil:
+			t = Array
+		default:
+			return nil, nil
+		}
+	}
+
+	switch t {
+	case Object:
+		return g.sampleObject(path, s)
+	case Array:
+		return g.sampleArray(path, s)
+	case String:
+		return sampleString(path, s)
+	case Integer, Number:
+		return sampleNumber(path, s, t == Integer)
+	case Boolean:
+		return true, nil
+	}
+
+	return nil, nil
+}
+
+func (g *sampler) sampleObject(path string, s Schema) (interface{}, error) {
+	obj := map[string]interface{}{}
+	required := make(map[string]bool, len(s.Required))
+
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(s.Properties))
+
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		ps := s.Properties[name]
+
+		v, err := g.sampleSchemaOrBool(path+"/properties/"+escapePointer(name), &ps)
+		if err != nil {
+			if required[name] || !errors.Is(err, errCyclicReference) {
+				return nil, err
+			}
+
+			continue
+		}
+
+		obj[name] = v
+	}
+
+	for _, name := range s.Required {
+		if _, found := obj[name]; found {
+			continue
+		}
+
+		v, err := g.sampleSchemaOrBool(path+"/additionalProperties", s.AdditionalProperties)
+		if err != nil {
+			return nil, err
+		}
+
+		obj[name] = v
+	}
+
+	return obj, nil
+}
+
+func (g *sampler) sampleArray(path string, s Schema) (interface{}, error) {
+	items := []interface{}{}
+
+	if s.Items != nil && s.Items.SchemaArray != nil {
+		for i := range s.Items.SchemaArray {
+			v, err := g.sampleSchemaOrBool(fmt.Sprintf("%s/items/%d", path, i), &s.Items.SchemaArray[i])
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, v)
+		}
+
+		return items, nil
+	}
+
+	count := s.MinItems
+	if count == 0 && (s.MaxItems == nil || *s.MaxItems > 0) {
+		count = 1
+	}
+
+	var itemSchema *SchemaOrBool
+	if s.Items != nil {
+		itemSchema = s.Items.SchemaOrBool
+	}
+
+	for i := int64(0); i < count; i++ {
+		v, err := g.sampleSchemaOrBool(path+"/items", itemSchema)
+		if err != nil {
+			return nil, err
+		}
+
+		// Distinct enum values are used for unique items.
+		if s.UniqueItems != nil && *s.UniqueItems && itemSchema != nil && itemSchema.TypeObject != nil &&
+			int64(len(itemSchema.TypeObject.Enum)) > i {
+			v = itemSchema.TypeObject.Enum[i]
+		}
+
+		items = append(items, v)
+	}
+
+	return items, nil
+}
+
+func sampleString(path string, s Schema) (interface{}, error) {
+	candidates := []string{"string", "abc", "a", "1", "A", "abc123", ""}
+
+	if s.Format != nil {
+		if fs, ok := formatSamples[*s.Format]; ok {
+			candidates = []string{fs}
+		}
+	}
+
+	var re *regexp.Regexp
+
+	if s.Pattern != nil {
+		var err error
+
+		if re, err = regexp.Compile(*s.Pattern); err != nil {
+			return nil, fmt.Errorf("%s: invalid pattern: %w", path, err)
+		}
+	}
+
+	for _, c := range candidates {
+		if n := utf8.RuneCountInString(c); int64(n) < s.MinLength {
+			c += strings.Repeat("x", int(s.MinLength)-n)
+		}
+
+		if s.MaxLength != nil && int64(utf8.RuneCountInString(c)) > *s.MaxLength {
+			c = string([]rune(c)[:*s.MaxLength])
+		}
+
+		if re == nil || re.MatchString(c) {
+			return c, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: can not generate string for pattern %s", path, *s.Pattern)
+}
+
+func sampleNumber(path string, s Schema, integer bool) (interface{}, error) {
+	lo, hi := math.Inf(-1), math.Inf(1)
+	loExcl, hiExcl := false, false
+
+	if s.Minimum != nil {
+		lo = *s.Minimum
+	}
+
+	if s.Maximum != nil {
+		hi = *s.Maximum
+	}
+
+	if s.ExclusiveMinimum != nil && *s.ExclusiveMinimum >= lo {
+		lo, loExcl = *s.ExclusiveMinimum, true
+	}
+
+	if s.ExclusiveMaximum != nil && *s.ExclusiveMaximum <= hi {
+		hi, hiExcl = *s.ExclusiveMaximum, true
+	}
+
+	valid := func(v float64) bool {
+		return v >= lo && v <= hi && !(loExcl && v == lo) && !(hiExcl && v == hi) &&
+			(s.MultipleOf == nil || isMultiple(v, *s.MultipleOf)) && (!integer || v == math.Trunc(v))
+	}
+
+	v := 0.0
+
+	if !valid(v) {
+		v = searchNumber(s, lo, hi, integer, valid)
+	}
+
+	if !valid(v) {
+		return nil, fmt.Errorf("%s: can not generate number within bounds", path)
+	}
+
+	if integer {
+		return int64(v), nil
+	}
+
+	return v, nil
+}
+
+// searchNumber walks from the finite bound towards the other one until a valid value is found.
+//
+// Steps are multipleOf if it is defined, 1 for integers, or a half of the range otherwise.
+func searchNumber(s Schema, lo, hi float64, integer bool, valid func(v float64) bool) float64 {
+	from, dir := lo, 1.0
+	if math.IsInf(lo, 0) {
+		from, dir = hi, -1.0
+	}
+
+	round := math.Ceil
+	if dir < 0 {
+		round = math.Floor
+	}
+
+	step := 1.0
+
+	switch {
+	case s.MultipleOf != nil && *s.MultipleOf > 0:
+		step = *s.MultipleOf
+		from = round(from / step)
+	case integer:
+		from = round(from)
+	case !math.IsInf(lo, 0) && !math.IsInf(hi, 0) && hi > lo:
+		step = (hi - lo) / 2
+		from /= step
+	}
+
+	for i := 0; i < 100; i++ {
+		v := (from + dir*float64(i)) * step
+		if valid(v) {
+			return v
+		}
+	}
+
+	return math.NaN()
+}
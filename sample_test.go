@@ -0,0 +1,86 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestGenerateExample(t *testing.T) {
+	type Item struct {
+		ID      int               `json:"id" minimum:"10" multipleOf:"4" required:"true"`
+		Price   float64           `json:"price" exclusiveMinimum:"0" maximum:"0.5"`
+		Kind    string            `json:"kind" enum:"a,b"`
+		Status  string            `json:"status" default:"active"`
+		Email   string            `json:"email" format:"email"`
+		Code    string            `json:"code" minLength:"5" maxLength:"6" pattern:"^[a-z]+$"`
+		Tags    []string          `json:"tags" minItems:"2"`
+		Enabled bool              `json:"enabled"`
+		Meta    map[string]string `json:"meta"`
+		Parent  *Item             `json:"parent"`
+	}
+
+	type Order struct {
+		Items []Item `json:"items" required:"true" minItems:"1"`
+		Main  Item   `json:"main"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(Order{})
+	require.NoError(t, err)
+
+	v, err := jsonschema.GenerateExample(s)
+	require.NoError(t, err)
+
+	item := `{
+	  "code":"string","email":"user@example.com","enabled":true,"id":12,"kind":"a","meta":{},
+	  "price":0.25,"status":"active","tags":["string","string"]
+	}`
+
+	assertjson.EqMarshal(t, `{"items":[`+item+`],"main":`+item+`}`, v)
+
+	type Invalid struct {
+		Code string `json:"code" pattern:"^[0-9]{3}$"`
+	}
+
+	s, err = r.Reflect(Invalid{})
+	require.NoError(t, err)
+
+	_, err = jsonschema.GenerateExample(s)
+	assert.EqualError(t, err, "#/properties/code: can not generate string for pattern ^[0-9]{3}$")
+}
+
+func TestGenerateExample_numberBounds(t *testing.T) {
+	for _, tc := range []struct {
+		schema string
+		value  string
+	}{
+		{`{"type":"number","exclusiveMinimum":0,"maximum":0.5,"multipleOf":0.1}`, `0.1`},
+		{`{"type":"number","exclusiveMinimum":0,"exclusiveMaximum":0.5}`, `0.25`},
+		{`{"type":"number","minimum":3.5,"maximum":3.5}`, `3.5`},
+		{`{"type":"number","exclusiveMaximum":-1}`, `-2`},
+		{`{"type":"integer","exclusiveMinimum":1.5,"multipleOf":3}`, `3`},
+		{`{"type":"integer","minimum":10,"maximum":20,"multipleOf":7}`, `14`},
+		{`{"type":"integer","maximum":-3,"multipleOf":2}`, `-4`},
+	} {
+		var s jsonschema.Schema
+
+		require.NoError(t, s.UnmarshalJSON([]byte(tc.schema)))
+
+		v, err := jsonschema.GenerateExample(s)
+		require.NoError(t, err, tc.schema)
+		assertjson.EqMarshal(t, tc.value, v, tc.schema)
+		assert.NoError(t, s.ValidateInterface(v), tc.schema)
+	}
+
+	var s jsonschema.Schema
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`{"type":"integer","exclusiveMinimum":1,"exclusiveMaximum":2}`)))
+
+	_, err := jsonschema.GenerateExample(s)
+	assert.EqualError(t, err, "#: can not generate number within bounds")
+}
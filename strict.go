@@ -0,0 +1,127 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// knownFormats is a vocabulary of standard and widely used values of `format` keyword.
+var knownFormats = map[string]bool{
+	"date-time":             true,
+	"date":                  true,
+	"time":                  true,
+	"duration":              true,
+	"email":                 true,
+	"idn-email":             true,
+	"hostname":              true,
+	"idn-hostname":          true,
+	"ipv4":                  true,
+	"ipv6":                  true,
+	"uri":                   true,
+	"uri-reference":         true,
+	"iri":                   true,
+	"iri-reference":         true,
+	"uri-template":          true,
+	"uuid":                  true,
+	"json-pointer":          true,
+	"relative-json-pointer": true,
+	"regex":                 true,
+	"int32":                 true,
+	"int64":                 true,
+	"float":                 true,
+	"double":                true,
+	"byte":                  true,
+	"binary":                true,
+	"base64":                true,
+	"decimal":               true,
+	"password":              true,
+}
+
+// StrictTags enables validation of `format` tag values and applicability of constraint tags to property type.
+//
+// Unknown formats can be allowed with Reflector.AddFormats.
+func StrictTags(rc *ReflectContext) {
+	rc.StrictTags = true
+}
+
+// AddFormats adds custom values to the vocabulary of formats used with StrictTags option.
+func (r *Reflector) AddFormats(formats ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.defCache = nil
+
+	if r.formats == nil {
+		r.formats = map[string]bool{}
+	}
+
+	for _, f := range formats {
+		r.formats[f] = true
+	}
+}
+
+// typedTags lists constraint tags with property types they are applicable to.
+var typedTags = []struct {
+	types []SimpleType
+	tags  []string
+}{
+	{types: []SimpleType{String}, tags: []string{"minLength", "maxLength", "pattern"}},
+	{types: []SimpleType{String, Integer, Number}, tags: []string{"format"}}, // E.g. int64 or double.
+	{
+		types: []SimpleType{Integer, Number},
+		tags:  []string{"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"},
+	},
+	{types: []SimpleType{Array}, tags: []string{"minItems", "maxItems", "uniqueItems"}},
+	{types: []SimpleType{Object}, tags: []string{"minProperties", "maxProperties"}},
+}
+
+// checkStrictTags validates format value and checks that constraint tags match property type.
+func (r *Reflector) checkStrictTags(rc *ReflectContext, field reflect.StructField, propertySchema *Schema) error {
+	path := strings.Join(append(rc.Path[1:], field.Name), ".")
+
+	if format, ok := field.Tag.Lookup("format"); ok && !knownFormats[format] && !r.formats[format] {
+		return fmt.Errorf("%s: unknown format %q", path, format)
+	}
+
+	if propertySchema.Type == nil {
+		return nil
+	}
+
+	for _, tt := range typedTags {
+		applicable := false
+
+		for _, t := range tt.types {
+			if propertySchema.HasType(t) {
+				applicable = true
+
+				break
+			}
+		}
+
+		if applicable {
+			continue
+		}
+
+		for _, tag := range tt.tags {
+			if _, ok := field.Tag.Lookup(tag); ok {
+				return fmt.Errorf("%s: %s is not applicable to %s", path, tag, typeName(propertySchema.Type))
+			}
+		}
+	}
+
+	return nil
+}
+
+func typeName(t *Type) string {
+	if t.SimpleTypes != nil {
+		return string(*t.SimpleTypes)
+	}
+
+	names := make([]string, 0, len(t.SliceOfSimpleTypeValues))
+	for _, st := range t.SliceOfSimpleTypeValues {
+		names = append(names, string(st))
+	}
+
+	return strings.Join(names, ", ")
+}
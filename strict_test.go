@@ -0,0 +1,67 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestStrictTags(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	type Valid struct {
+		Foo string      `json:"foo" format:"email" minLength:"3"`
+		Bar *int        `json:"bar" minimum:"1"`
+		Baz []string    `json:"baz" minItems:"1"`
+		Any interface{} `json:"any" minLength:"1"`
+		Qux int64       `json:"qux" format:"int64"`
+		Quu float64     `json:"quu" format:"double"`
+	}
+
+	s, err := r.Reflect(Valid{}, jsonschema.StrictTags)
+	require.NoError(t, err)
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"any":{"minLength":1},"bar":{"minimum":1,"type":["null","integer"]},
+		"baz":{"items":{"type":"string"},"minItems":1,"type":["array","null"]},
+		"foo":{"minLength":3,"type":"string","format":"email"},
+		"quu":{"type":"number","format":"double"},
+		"qux":{"type":"integer","format":"int64"}
+	  },
+	  "type":"object"
+	}`, s)
+
+	type UnknownFormat struct {
+		Foo string `json:"foo" format:"emial"`
+	}
+
+	_, err = r.Reflect(UnknownFormat{}, jsonschema.StrictTags)
+	assert.EqualError(t, err, `Foo: unknown format "emial"`)
+
+	_, err = r.Reflect(UnknownFormat{})
+	assert.NoError(t, err)
+
+	r.AddFormats("emial")
+
+	_, err = r.Reflect(UnknownFormat{}, jsonschema.StrictTags)
+	assert.NoError(t, err)
+
+	type Mismatch struct {
+		Nested struct {
+			Foo int `json:"foo" minLength:"3"`
+		} `json:"nested"`
+	}
+
+	_, err = r.Reflect(Mismatch{}, jsonschema.StrictTags)
+	assert.EqualError(t, err, `nested.Foo: minLength is not applicable to integer`)
+
+	type FormatMismatch struct {
+		Foo bool `json:"foo" format:"int32"`
+	}
+
+	_, err = r.Reflect(FormatMismatch{}, jsonschema.StrictTags)
+	assert.EqualError(t, err, `Foo: format is not applicable to boolean`)
+}
@@ -1,24 +1,45 @@
 package jsonschema
 
 import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"hash/fnv"
 	"reflect"
 	"strconv"
 
 	"github.com/swaggest/refl"
 )
 
-var structDefaultDefNameIndex = 0
+var typeOfStruct = reflect.TypeOf(Struct{})
 
 // Field mimics Go reflect.StructField for purposes of schema reflection.
+//
+// Value can be another Struct, it is reflected inline if it has no DefName.
 type Field struct {
 	Name  string
 	Value interface{}
 	Tag   reflect.StructTag
+
+	// Required marks property as required regardless of `required` tag.
+	Required bool
+
+	// Schema is used instead of reflecting Value if not nil, field tags are applied on top of it.
+	Schema *Schema
+
+	// Prepare alters reflected property schema, similar to PropertyPreparer, can be nil.
+	Prepare func(schema *Schema) error
+}
+
+// fieldSchema carries Field.Schema of virtual struct to property reflection.
+type fieldSchema struct {
+	schema *Schema
 }
 
 // Struct mimics Go struct to allow schema reflection on virtual struct type.
 //
 // This can be handy for dynamic values that can not be represented as static Go structures.
+// Nested Struct without DefName is reflected inline, otherwise a definition is created.
 type Struct struct {
 	Title       *string
 	Description *string
@@ -50,10 +71,121 @@ func (s Struct) names() (string, refl.TypeString) {
 	defName := s.DefName
 
 	if defName == "" {
-		structDefaultDefNameIndex++
-
-		defName = "struct" + strconv.Itoa(structDefaultDefNameIndex)
+		defName = "struct" + s.hash()
 	}
 
 	return defName, refl.TypeString("struct." + defName)
 }
+
+// hash returns a short content hash of Struct, so that unnamed definitions have deterministic names.
+func (s Struct) hash() string {
+	h := fnv.New32a()
+
+	// Errors are ignored, values that can not be marshaled are only represented by their types.
+	_ = json.NewEncoder(h).Encode([]interface{}{s.Title, s.Description, s.Nullable}) //nolint:errchkjson
+
+	for _, f := range s.Fields {
+		_, _ = fmt.Fprintf(h, "%q %q %T %t %t\n", f.Name, f.Tag, f.Value, f.Required, f.Prepare != nil)
+		_ = json.NewEncoder(h).Encode([]interface{}{f.Value, f.Schema}) //nolint:errchkjson
+	}
+
+	return strconv.FormatUint(uint64(h.Sum32()), 36)
+}
+
+// ToReflectType creates a dynamic Go struct type with fields and tags of virtual struct.
+//
+// Resulting type can be used to decode JSON with encoding/json or to reflect schema.
+// Nested Struct values (and slices, arrays, maps and pointers of them) are converted recursively,
+// the first element of a collection is used as a sample. Fields with Schema and without Value
+// are of interface{} type.
+// Title, Description, Nullable and DefName of Struct and Prepare of fields are not retained.
+func (s Struct) ToReflectType() (reflect.Type, error) {
+	fields := make([]reflect.StructField, 0, len(s.Fields))
+	names := make(map[string]bool, len(s.Fields))
+
+	for _, f := range s.Fields {
+		if !token.IsIdentifier(f.Name) || !token.IsExported(f.Name) {
+			return nil, fmt.Errorf("invalid field name %q, exported identifier expected", f.Name)
+		}
+
+		if names[f.Name] {
+			return nil, fmt.Errorf("duplicate field name %s", f.Name)
+		}
+
+		names[f.Name] = true
+
+		var (
+			t   reflect.Type
+			err error
+		)
+
+		switch {
+		case f.Value != nil:
+			t, err = structFieldType(reflect.TypeOf(f.Value), reflect.ValueOf(f.Value))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.Name, err)
+			}
+		case f.Schema != nil:
+			t = reflect.TypeOf((*interface{})(nil)).Elem()
+		default:
+			return nil, fmt.Errorf("%s: value or schema is required", f.Name)
+		}
+
+		tag := f.Tag
+		if f.Required {
+			tag = `required:"true" ` + tag
+		}
+
+		fields = append(fields, reflect.StructField{Name: f.Name, Type: t, Tag: tag})
+	}
+
+	return reflect.StructOf(fields), nil
+}
+
+// structFieldType replaces virtual Struct in field type with a dynamic Go struct type.
+func structFieldType(t reflect.Type, v reflect.Value) (reflect.Type, error) {
+	if t == typeOfStruct {
+		return v.Interface().(Struct).ToReflectType() //nolint:forcetypeassert // Type is checked.
+	}
+
+	elem := func() (reflect.Type, error) {
+		var ev reflect.Value
+
+		switch {
+		case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && v.IsValid() && v.Len() > 0:
+			ev = v.Index(0)
+		case t.Kind() == reflect.Map && v.IsValid() && v.Len() > 0:
+			iter := v.MapRange()
+			iter.Next()
+			ev = iter.Value()
+		case t.Kind() == reflect.Ptr && v.IsValid() && !v.IsNil():
+			ev = v.Elem()
+		default:
+			ev = reflect.Zero(t.Elem())
+		}
+
+		return structFieldType(t.Elem(), ev)
+	}
+
+	//nolint:exhaustive // Other kinds can not contain Struct.
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Ptr, reflect.Map:
+		et, err := elem()
+		if err != nil || et == t.Elem() {
+			return t, err
+		}
+
+		switch t.Kind() {
+		case reflect.Slice:
+			return reflect.SliceOf(et), nil
+		case reflect.Array:
+			return reflect.ArrayOf(t.Len(), et), nil
+		case reflect.Ptr:
+			return reflect.PtrTo(et), nil
+		default:
+			return reflect.MapOf(t.Key(), et), nil
+		}
+	}
+
+	return t, nil
+}
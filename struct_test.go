@@ -1,8 +1,14 @@
 package jsonschema_test
 
 import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/swaggest/assertjson"
 	"github.com/swaggest/jsonschema-go"
@@ -97,14 +103,13 @@ func TestReflector_Reflect_Struct(t *testing.T) {
 		"TestStruct2":{
 		  "title":"T2","properties":{"quux":{"minLength":3,"type":"string"}},
 		  "type":"object"
-		},
-		"struct1":{
-		  "title":"T2","properties":{"quux":{"minLength":3,"type":"string"}},
-		  "type":"object"
 		}
 	  },
 	  "properties":{
-		"another":{"$ref":"#/definitions/struct1"},
+		"another":{
+		  "title":"T2","properties":{"quux":{"minLength":3,"type":"string"}},
+		  "type":"object"
+		},
 		"b4r":{"minimum":3,"type":"integer"},
 		"b4z":{"items":{"type":"integer"},"minItems":4,"type":["array","null"]},
 		"fo0":{"minLength":3,"type":"string"},
@@ -116,6 +121,77 @@ func TestReflector_Reflect_Struct(t *testing.T) {
 	}`, sc)
 }
 
+func TestReflector_Reflect_Struct_nested(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	address := jsonschema.Struct{}
+	address.Fields = []jsonschema.Field{
+		{Name: "City", Value: "", Tag: `json:"city"`, Required: true},
+		{Name: "Zip", Value: "", Tag: `json:"zip" pattern:"^[0-9]+$"`},
+	}
+
+	s := jsonschema.Struct{DefName: "Form"}
+	s.Fields = []jsonschema.Field{
+		{Name: "Name", Value: "", Tag: `json:"name" required:"false"`, Required: true},
+		{Name: "Address", Value: address, Tag: `json:"address" description:"Postal address."`, Required: true},
+		{Name: "Previous", Value: []jsonschema.Struct{address}, Tag: `json:"previous"`},
+	}
+
+	sc, err := r.Reflect(s)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "required":["name","address"],
+	  "properties":{
+		"address":{
+		  "description":"Postal address.","required":["city"],
+		  "properties":{"city":{"type":"string"},"zip":{"pattern":"^[0-9]+$","type":"string"}},
+		  "type":"object"
+		},
+		"name":{"type":"string"},
+		"previous":{
+		  "items":{
+			"required":["city"],
+			"properties":{"city":{"type":"string"},"zip":{"pattern":"^[0-9]+$","type":"string"}},
+			"type":"object"
+		  },
+		  "type":["array","null"]
+		}
+	  },
+	  "type":"object"
+	}`, sc)
+}
+
+func TestReflector_Reflect_Struct_fieldSchema(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	email := (&jsonschema.Schema{}).WithType(jsonschema.String.Type()).WithFormat("email")
+	tags := (&jsonschema.Schema{}).WithType(jsonschema.Array.Type()).
+		WithItems(*(&jsonschema.Items{}).WithSchemaOrBool(jsonschema.String.ToSchemaOrBool()))
+
+	s := jsonschema.Struct{}
+	s.Fields = []jsonschema.Field{
+		{Name: "Email", Schema: email, Tag: `json:"email" maxLength:"64" description:"Contact email."`, Required: true},
+		{Name: "Tags", Schema: tags, Tag: `json:"tags"`},
+		{Name: "Backup", Schema: email, Tag: `json:"backup" nullable:"true"`},
+	}
+
+	sc, err := r.Reflect(s)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "required":["email"],
+	  "properties":{
+		"backup":{"format":"email","type":["string","null"]},
+		"email":{"description":"Contact email.","format":"email","maxLength":64,"type":"string"},
+		"tags":{"items":{"type":"string"},"type":"array"}
+	  },
+	  "type":"object"
+	}`, sc)
+
+	assertjson.EqMarshal(t, `{"format":"email","type":"string"}`, email)
+}
+
 func TestReflector_Reflect_StructEmbed(t *testing.T) {
 	type dynamicInput struct {
 		jsonschema.Struct
@@ -172,7 +248,7 @@ func TestReflector_Reflect_StructEmbed(t *testing.T) {
 		"DynIn123":{
 		  "properties":{
 			"bar":{"type":"string"},
-			"foo":{"enum":["123","456","789"],"type":"integer"},
+			"foo":{"enum":[123,456,789],"type":"integer"},
 			"type":{"type":"string"}
 		  },
 		  "type":"object"
@@ -180,7 +256,7 @@ func TestReflector_Reflect_StructEmbed(t *testing.T) {
 		"DynOut123":{
 		  "properties":{
 			"bar":{"type":"string"},
-			"foo":{"enum":["123","456","789"],"type":"integer"},
+			"foo":{"enum":[123,456,789],"type":"integer"},
 			"status":{"type":"string"}
 		  },
 		  "type":"object"
@@ -244,3 +320,137 @@ func TestReflector_Reflect_StructExample(t *testing.T) {
 		}`, schema)
 	})
 }
+
+func TestStruct_ToReflectType(t *testing.T) {
+	address := jsonschema.Struct{}
+	address.Fields = []jsonschema.Field{
+		{Name: "City", Value: "", Tag: `json:"city" minLength:"1"`, Required: true},
+	}
+
+	s := jsonschema.Struct{DefName: "Form"}
+	s.Fields = []jsonschema.Field{
+		{Name: "Name", Value: "", Tag: `json:"name"`},
+		{Name: "Age", Value: 0, Tag: `json:"age,omitempty" minimum:"0"`},
+		{Name: "Address", Value: address, Tag: `json:"address"`},
+		{Name: "Previous", Value: []jsonschema.Struct{address}, Tag: `json:"previous"`},
+		{Name: "Extra", Schema: &jsonschema.Schema{}, Tag: `json:"extra"`},
+	}
+
+	rt, err := s.ToReflectType()
+	require.NoError(t, err)
+
+	v := reflect.New(rt)
+	require.NoError(t, json.Unmarshal(
+		[]byte(`{"name":"Jane","age":30,"address":{"city":"Berlin"},"previous":[{"city":"Paris"}],"extra":[1]}`),
+		v.Interface(),
+	))
+
+	j, err := json.Marshal(v.Interface())
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"Jane","age":30,"address":{"city":"Berlin"},"previous":[{"city":"Paris"}],"extra":[1]}`, string(j))
+
+	r := jsonschema.Reflector{}
+
+	sc, err := r.Reflect(reflect.New(rt).Elem().Interface())
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"address":{
+		  "required":["city"],"properties":{"city":{"minLength":1,"type":"string"}},"type":"object"
+		},
+		"age":{"minimum":0,"type":"integer"},"extra":{},"name":{"type":"string"},
+		"previous":{
+		  "items":{
+			"required":["city"],"properties":{"city":{"minLength":1,"type":"string"}},"type":"object"
+		  },
+		  "type":["array","null"]
+		}
+	  },
+	  "type":"object"
+	}`, sc)
+
+	_, err = jsonschema.Struct{Fields: []jsonschema.Field{{Name: "foo", Value: ""}}}.ToReflectType()
+	assert.EqualError(t, err, `invalid field name "foo", exported identifier expected`)
+
+	_, err = jsonschema.Struct{Fields: []jsonschema.Field{{Name: "Foo", Value: ""}, {Name: "Foo", Value: 1}}}.ToReflectType()
+	assert.EqualError(t, err, `duplicate field name Foo`)
+
+	_, err = jsonschema.Struct{Fields: []jsonschema.Field{{Name: "Foo"}}}.ToReflectType()
+	assert.EqualError(t, err, `Foo: value or schema is required`)
+}
+
+func TestReflector_Reflect_Struct_unnamed(t *testing.T) {
+	newStruct := func(tag string) jsonschema.Struct {
+		s := jsonschema.Struct{}
+		s.Fields = []jsonschema.Field{{Name: "Foo", Value: "", Tag: reflect.StructTag(tag)}}
+
+		return s
+	}
+
+	refs := make([]string, 20)
+	wg := sync.WaitGroup{}
+
+	for i := range refs {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			r := jsonschema.Reflector{}
+
+			s, err := r.Reflect(newStruct(`json:"foo`+strconv.Itoa(i%2)+`"`), jsonschema.RootRef)
+			if assert.NoError(t, err) && assert.NotNil(t, s.Ref) {
+				refs[i] = *s.Ref
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i := range refs {
+		assert.Equal(t, refs[i%2], refs[i])
+	}
+
+	assert.NotEqual(t, refs[0], refs[1])
+	assert.Regexp(t, `^#/definitions/struct[0-9a-z]+$`, refs[0])
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(newStruct(`json:"foo0"`), jsonschema.RootRef)
+	require.NoError(t, err)
+	assert.Equal(t, refs[0], *s.Ref)
+}
+
+func TestReflector_Reflect_Struct_fieldPrepare(t *testing.T) {
+	r := jsonschema.Reflector{}
+
+	s := jsonschema.Struct{DefName: "Form"}
+	s.Fields = []jsonschema.Field{
+		{Name: "Color", Value: "", Tag: `json:"color"`, Prepare: func(schema *jsonschema.Schema) error {
+			schema.WithEnum("red", "green")
+			schema.WithExtraPropertiesItem("x-widget", "select")
+
+			return nil
+		}},
+		{Name: "Size", Value: 0, Tag: `json:"size"`},
+	}
+
+	sc, err := r.Reflect(s)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"color":{"enum":["red","green"],"type":"string","x-widget":"select"},
+		"size":{"type":"integer"}
+	  },
+	  "type":"object"
+	}`, sc)
+
+	s.Fields[1].Prepare = func(schema *jsonschema.Schema) error {
+		return errors.New("failed")
+	}
+
+	_, err = r.Reflect(s)
+	assert.EqualError(t, err, "size: failed")
+}
@@ -0,0 +1,374 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// RenderTypeScript renders TypeScript declarations of schema and its definitions.
+//
+// Definitions are exported in alphabetical order followed by root schema (unless it is only a reference)
+// named by its title or "Schema":
+//   - objects with properties are exported as interfaces, optional properties are marked with `?`,
+//   - enums with names (XEnumNames) are exported as enums, other enums and `const` as literal unions,
+//   - references are exported as names of definitions, `oneOf` and `anyOf` as unions, `allOf` as intersections,
+//   - `null` type and OpenAPI `nullable` add `| null`,
+//   - arrays are exported as `T[]` or tuples, maps (objects without properties) as `Record<string, T>`.
+//
+// Other schemas are exported as type aliases, schemas without type are exported as `unknown`.
+func RenderTypeScript(s Schema) string {
+	var (
+		b    strings.Builder
+		defs = make([]string, 0, len(s.Definitions))
+	)
+
+	for name := range s.Definitions {
+		defs = append(defs, name)
+	}
+
+	sort.Strings(defs)
+
+	for _, name := range defs {
+		renderTypeScriptDecl(&b, tsIdentifier(name), s.Definitions[name])
+	}
+
+	if s.Ref == nil || len(s.Properties) > 0 {
+		name := "Schema"
+		if s.Title != nil {
+			name = tsIdentifier(*s.Title)
+		}
+
+		root := s
+		root.Definitions = nil
+
+		renderTypeScriptDecl(&b, name, root.ToSchemaOrBool())
+	}
+
+	return b.String()
+}
+
+func renderTypeScriptDecl(b *strings.Builder, name string, sb SchemaOrBool) {
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+
+	s := sb.TypeObject
+	if s == nil {
+		fmt.Fprintf(b, "export type %s = %s;\n", name, tsType(sb))
+
+		return
+	}
+
+	tsComment(b, "", s.Description)
+
+	if renderTypeScriptEnum(b, name, *s) {
+		return
+	}
+
+	if s.Ref != nil || len(s.Properties) == 0 || len(s.OneOf) > 0 || len(s.AnyOf) > 0 || len(s.AllOf) > 0 ||
+		s.HasType(Null) || tsNullable(*s) {
+		fmt.Fprintf(b, "export type %s = %s;\n", name, tsType(sb))
+
+		return
+	}
+
+	b.WriteString("export interface " + name + " {\n")
+	tsProperties(b, "  ", *s)
+	b.WriteString("}\n")
+}
+
+// renderTypeScriptEnum exports string enum with names as TypeScript enum.
+func renderTypeScriptEnum(b *strings.Builder, name string, s Schema) bool {
+	names, ok := s.ExtraSlice(XEnumNames)
+	if !ok || len(names) != len(s.Enum) {
+		return false
+	}
+
+	members := make([]string, 0, len(names))
+
+	for i, n := range names {
+		v, ok := s.Enum[i].(string)
+		if !ok {
+			return false
+		}
+
+		ns, ok := n.(string)
+		if !ok || ns == "" || tsIdentifier(ns) != ns {
+			return false
+		}
+
+		members = append(members, "  "+ns+" = "+tsLiteral(v)+",")
+	}
+
+	b.WriteString("export enum " + name + " {\n" + strings.Join(members, "\n") + "\n}\n")
+
+	return true
+}
+
+func tsProperties(b *strings.Builder, indent string, s Schema) {
+	required := make(map[string]bool, len(s.Required))
+
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(s.Properties))
+
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop := s.Properties[name]
+
+		if prop.TypeObject != nil {
+			tsComment(b, indent, prop.TypeObject.Description)
+		}
+
+		optional := "?"
+		if required[name] {
+			optional = ""
+		}
+
+		key := name
+		if tsIdentifier(name) != name {
+			key = tsLiteral(name)
+		}
+
+		b.WriteString(indent + key + optional + ": " + tsTypeIndent(prop, indent) + ";\n")
+	}
+}
+
+func tsComment(b *strings.Builder, indent string, description *string) {
+	if description == nil || *description == "" {
+		return
+	}
+
+	lines := strings.Split(strings.ReplaceAll(*description, "*/", "*\\/"), "\n")
+	if len(lines) == 1 {
+		b.WriteString(indent + "/** " + lines[0] + " */\n")
+
+		return
+	}
+
+	b.WriteString(indent + "/**\n")
+
+	for _, l := range lines {
+		b.WriteString(strings.TrimRight(indent+" * "+l, " ") + "\n")
+	}
+
+	b.WriteString(indent + " */\n")
+}
+
+func tsType(sb SchemaOrBool) string {
+	return tsTypeIndent(sb, "")
+}
+
+// tsTypeIndent returns TypeScript type expression, indent is used for inline object literals.
+func tsTypeIndent(sb SchemaOrBool, indent string) string {
+	if sb.TypeObject == nil {
+		if sb.TypeBoolean != nil && !*sb.TypeBoolean {
+			return "never"
+		}
+
+		return "unknown"
+	}
+
+	s := *sb.TypeObject
+
+	var types []string
+
+	switch {
+	case s.Ref != nil:
+		types = append(types, tsRef(*s.Ref))
+	case s.Const != nil:
+		types = append(types, tsLiteral(*s.Const))
+	case len(s.Enum) > 0:
+		for _, v := range s.Enum {
+			types = append(types, tsLiteral(v))
+		}
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0:
+		for _, v := range append(append([]SchemaOrBool{}, s.OneOf...), s.AnyOf...) {
+			types = append(types, tsTypeIndent(v, indent))
+		}
+	case len(s.AllOf) > 0:
+		parts := make([]string, 0, len(s.AllOf))
+
+		for _, v := range s.AllOf {
+			parts = append(parts, tsWrap(tsTypeIndent(v, indent)))
+		}
+
+		types = append(types, strings.Join(parts, " & "))
+	default:
+		types = tsSimpleTypes(s, indent)
+	}
+
+	if (s.HasType(Null) || tsNullable(s)) && !tsHas(types, "null") {
+		types = append(types, "null")
+	}
+
+	return strings.Join(tsUnique(types), " | ")
+}
+
+func tsSimpleTypes(s Schema, indent string) []string {
+	simpleTypes := s.simpleTypes()
+
+	if len(simpleTypes) == 0 {
+		switch {
+		case len(s.Properties) > 0:
+			simpleTypes = []SimpleType{Object}
+		case s.Items != nil:
+			simpleTypes = []SimpleType{Array}
+		default:
+			return []string{"unknown"}
+		}
+	}
+
+	types := make([]string, 0, len(simpleTypes))
+
+	for _, t := range simpleTypes {
+		switch t {
+		case String:
+			types = append(types, "string")
+		case Integer, Number:
+			types = append(types, "number")
+		case Boolean:
+			types = append(types, "boolean")
+		case Null:
+			types = append(types, "null")
+		case Array:
+			types = append(types, tsArray(s, indent))
+		case Object:
+			types = append(types, tsObject(s, indent))
+		}
+	}
+
+	return types
+}
+
+func tsArray(s Schema, indent string) string {
+	if s.Items == nil {
+		return "unknown[]"
+	}
+
+	if s.Items.SchemaArray != nil {
+		items := make([]string, 0, len(s.Items.SchemaArray))
+
+		for _, item := range s.Items.SchemaArray {
+			items = append(items, tsTypeIndent(item, indent))
+		}
+
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+
+	if s.Items.SchemaOrBool == nil {
+		return "unknown[]"
+	}
+
+	return tsWrap(tsTypeIndent(*s.Items.SchemaOrBool, indent)) + "[]"
+}
+
+func tsObject(s Schema, indent string) string {
+	if len(s.Properties) == 0 {
+		if s.AdditionalProperties != nil {
+			return "Record<string, " + tsTypeIndent(*s.AdditionalProperties, indent) + ">"
+		}
+
+		return "Record<string, unknown>"
+	}
+
+	var b strings.Builder
+
+	b.WriteString("{\n")
+	tsProperties(&b, indent+"  ", s)
+	b.WriteString(indent + "}")
+
+	return b.String()
+}
+
+func tsNullable(s Schema) bool {
+	nullable, ok := s.ExtraBool("nullable")
+
+	return ok && nullable
+}
+
+// tsRef returns name of referenced definition.
+func tsRef(ref string) string {
+	pos := strings.LastIndex(ref, "/")
+	if !strings.HasPrefix(ref, "#/") || pos == -1 {
+		return "unknown"
+	}
+
+	return tsIdentifier(strings.ReplaceAll(strings.ReplaceAll(ref[pos+1:], "~1", "/"), "~0", "~"))
+}
+
+// tsIdentifier replaces characters that are not allowed in TypeScript identifier with `_`.
+func tsIdentifier(name string) string {
+	var b strings.Builder
+
+	for i, r := range name {
+		switch {
+		case r == '_' || r == '$' || unicode.IsLetter(r):
+			b.WriteRune(r)
+		case unicode.IsDigit(r):
+			if i == 0 {
+				b.WriteRune('_')
+			}
+
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+
+	if b.Len() == 0 {
+		return "_"
+	}
+
+	return b.String()
+}
+
+func tsLiteral(v interface{}) string {
+	j, err := json.Marshal(v)
+	if err != nil {
+		return "unknown"
+	}
+
+	return string(j)
+}
+
+// tsWrap adds parentheses to union and intersection types.
+func tsWrap(t string) string {
+	if strings.Contains(t, " | ") || strings.Contains(t, " & ") {
+		return "(" + t + ")"
+	}
+
+	return t
+}
+
+func tsHas(types []string, t string) bool {
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+func tsUnique(types []string) []string {
+	res := make([]string, 0, len(types))
+
+	for _, t := range types {
+		if !tsHas(res, t) {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
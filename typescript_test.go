@@ -0,0 +1,83 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/jsonschema-go"
+)
+
+type tsStatus string
+
+func (tsStatus) NamedEnum() ([]interface{}, []string) {
+	return []interface{}{"new", "paid"}, []string{"New", "Paid"}
+}
+
+func TestRenderTypeScript(t *testing.T) {
+	type Item struct {
+		Name  string  `json:"name" required:"true" description:"Item name."`
+		Price float64 `json:"price"`
+	}
+
+	type Order struct {
+		ID      int               `json:"id" required:"true"`
+		Status  tsStatus          `json:"status"`
+		Kind    string            `json:"kind" enum:"online,offline"`
+		Items   []Item            `json:"items"`
+		Pair    [2]int            `json:"pair" description:"Multiline\ndescription."`
+		Labels  map[string]string `json:"labels"`
+		Parent  *Order            `json:"parent"`
+		Data    interface{}       `json:"data"`
+		Address struct {
+			City string `json:"city"`
+		} `json:"address"`
+		Weird bool `json:"weird-name"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(Order{}, jsonschema.RootRef)
+	require.NoError(t, err)
+
+	s.Definitions["Payment"] = (&jsonschema.Schema{}).WithOneOf(
+		(&jsonschema.Schema{}).WithRef("#/definitions/JsonschemaGoTestItem").ToSchemaOrBool(),
+		(&jsonschema.Schema{}).WithType(jsonschema.String.Type()).ToSchemaOrBool(),
+	).WithExtraPropertiesItem("nullable", true).ToSchemaOrBool()
+
+	assert.Equal(t, `export interface JsonschemaGoTestItem {
+  /** Item name. */
+  name: string;
+  price?: number;
+}
+
+export interface JsonschemaGoTestOrder {
+  address?: {
+    city?: string;
+  };
+  data?: unknown;
+  id: number;
+  items?: JsonschemaGoTestItem[] | null;
+  kind?: "online" | "offline";
+  labels?: Record<string, string> | null;
+  /**
+   * Multiline
+   * description.
+   */
+  pair?: number[] | null;
+  parent?: JsonschemaGoTestOrder;
+  status?: JsonschemaGoTestTsStatus;
+  "weird-name"?: boolean;
+}
+
+export enum JsonschemaGoTestTsStatus {
+  New = "new",
+  Paid = "paid",
+}
+
+export type Payment = JsonschemaGoTestItem | string | null;
+`, jsonschema.RenderTypeScript(s))
+
+	assert.Equal(t, "/** Identifier. */\nexport type ID = number;\n", jsonschema.RenderTypeScript(
+		*(&jsonschema.Schema{}).WithTitle("ID").WithDescription("Identifier.").WithType(jsonschema.Integer.Type())))
+}
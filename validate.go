@@ -0,0 +1,211 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// validatorFormats maps go-playground/validator rules to JSON Schema formats.
+var validatorFormats = map[string]string{
+	"email":    "email",
+	"uuid":     "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"url":      "uri",
+	"uri":      "uri",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+	"fqdn":     "hostname",
+}
+
+// validatorPatterns maps go-playground/validator rules to JSON Schema patterns.
+var validatorPatterns = map[string]string{
+	"alpha":    "^[a-zA-Z]+$",
+	"alphanum": "^[a-zA-Z0-9]+$",
+	"numeric":  "^[-+]?[0-9]+(?:\\.[0-9]+)?$",
+}
+
+// ValidatorTags enables translation of go-playground/validator rules into schema keywords.
+//
+// Rules are read from `validate` field tag by default, alternative tag name can be provided.
+// Supported rules: required, min, max, len, gt, gte, lt, lte, oneof, email, uuid, url, uri,
+// ipv4, ipv6, hostname, fqdn, alpha, alphanum, numeric. Rules after `dive` are ignored.
+//
+// Explicit schema tags (e.g. `minLength`) take precedence over translated rules.
+func ValidatorTags(tagName ...string) func(rc *ReflectContext) {
+	name := "validate"
+	if len(tagName) > 0 {
+		name = tagName[0]
+	}
+
+	return InterceptProp(func(params InterceptPropParams) error {
+		if !params.Processed {
+			return nil
+		}
+
+		rules, ok := params.Field.Tag.Lookup(name)
+		if !ok {
+			return nil
+		}
+
+		if err := applyValidatorRules(params, rules); err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(append(params.Path[1:], params.Name), "."), err)
+		}
+
+		// Exclusive bounds of gt and lt rules are added after property schema was converted.
+		if params.Context.ExclusiveBoundsDraft04 {
+			exclusiveBoundsDraft04(params.PropertySchema)
+		}
+
+		return nil
+	})
+}
+
+func applyValidatorRules(params InterceptPropParams, rules string) error {
+	s := params.PropertySchema
+
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg := rule, ""
+		if i := strings.Index(rule, "="); i != -1 {
+			name, arg = rule[:i], rule[i+1:]
+		}
+
+		if name == "dive" {
+			return nil
+		}
+
+		if name == "required" {
+			if params.ParentSchema != nil {
+				addRequired(params.ParentSchema, params.Name)
+			}
+
+			continue
+		}
+
+		if s.Ref != nil {
+			continue
+		}
+
+		if format, ok := validatorFormats[name]; ok {
+			if _, found := params.Field.Tag.Lookup("format"); !found {
+				s.WithFormat(format)
+			}
+
+			continue
+		}
+
+		if pattern, ok := validatorPatterns[name]; ok {
+			if _, found := params.Field.Tag.Lookup("pattern"); !found {
+				s.WithPattern(pattern)
+			}
+
+			continue
+		}
+
+		if name == "oneof" {
+			if _, found := params.Field.Tag.Lookup("enum"); !found && arg != "" {
+				e, err := splitEnum(strings.Join(strings.Fields(arg), ","), s)
+				if err != nil {
+					return fmt.Errorf("oneof: %w", err)
+				}
+
+				s.Enum = e
+			}
+
+			continue
+		}
+
+		applyValidatorBound(params, name, arg)
+	}
+
+	return nil
+}
+
+func applyValidatorBound(params InterceptPropParams, name, arg string) {
+	s := params.PropertySchema
+
+	var lower, upper, exclusive bool
+
+	switch name {
+	case "min", "gte":
+		lower = true
+	case "max", "lte":
+		upper = true
+	case "gt":
+		lower, exclusive = true, true
+	case "lt":
+		upper, exclusive = true, true
+	case "len":
+		lower, upper = true, true
+	default:
+		return
+	}
+
+	set := func(keyword string, f func()) {
+		if _, found := params.Field.Tag.Lookup(keyword); !found {
+			f()
+		}
+	}
+
+	switch {
+	case s.HasType(Integer) || s.HasType(Number):
+		v, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return
+		}
+
+		switch {
+		case lower && exclusive:
+			set("exclusiveMinimum", func() { s.WithExclusiveMinimum(v) })
+		case upper && exclusive:
+			set("exclusiveMaximum", func() { s.WithExclusiveMaximum(v) })
+		default:
+			if lower {
+				set("minimum", func() { s.WithMinimum(v) })
+			}
+
+			if upper {
+				set("maximum", func() { s.WithMaximum(v) })
+			}
+		}
+
+		return
+	case exclusive:
+		// Exclusive bounds of lengths are not translated.
+		return
+	}
+
+	v, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case s.HasType(String):
+		if lower {
+			set("minLength", func() { s.WithMinLength(v) })
+		}
+
+		if upper {
+			set("maxLength", func() { s.WithMaxLength(v) })
+		}
+	case s.HasType(Array):
+		if lower {
+			set("minItems", func() { s.WithMinItems(v) })
+		}
+
+		if upper {
+			set("maxItems", func() { s.WithMaxItems(v) })
+		}
+	case s.HasType(Object):
+		if lower {
+			set("minProperties", func() { s.WithMinProperties(v) })
+		}
+
+		if upper {
+			set("maxProperties", func() { s.WithMaxProperties(v) })
+		}
+	}
+}
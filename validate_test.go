@@ -0,0 +1,72 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestValidatorTags(t *testing.T) {
+	type Item struct {
+		ID string `json:"id" validate:"required,uuid"`
+	}
+
+	type My struct {
+		Name   string         `json:"name" validate:"required,min=3,max=20,alphanum"`
+		Email  string         `json:"email" validate:"omitempty,email"`
+		Age    int            `json:"age" validate:"gte=18,lt=150"`
+		Score  float64        `json:"score" validate:"gt=0" exclusiveMinimum:"1"`
+		Kind   string         `json:"kind" validate:"oneof=a b c"`
+		Level  int            `json:"level" validate:"oneof=1 2 3"`
+		Tags   []string       `json:"tags" validate:"required,min=1,dive,min=2"`
+		Code   string         `json:"code" validate:"len=4"`
+		Meta   map[string]int `json:"meta" validate:"max=10"`
+		Item   Item           `json:"item" validate:"required,min=5"`
+		Custom string         `json:"custom" check:"url"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(My{}, jsonschema.ValidatorTags())
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "required":["name","tags","item"],
+	  "definitions":{
+		"JsonschemaGoTestItem":{
+		  "required":["id"],"properties":{"id":{"type":"string","format":"uuid"}},
+		  "type":"object"
+		}
+	  },
+	  "properties":{
+		"age":{"exclusiveMaximum":150,"minimum":18,"type":"integer"},
+		"code":{"maxLength":4,"minLength":4,"type":"string"},
+		"custom":{"type":"string"},
+		"email":{"type":"string","format":"email"},
+		"item":{"$ref":"#/definitions/JsonschemaGoTestItem"},
+		"kind":{"enum":["a","b","c"],"type":"string"},
+		"level":{"enum":[1,2,3],"type":"integer"},
+		"meta":{
+		  "additionalProperties":{"type":"integer"},"maxProperties":10,
+		  "type":["object","null"]
+		},
+		"name":{"maxLength":20,"minLength":3,"pattern":"^[a-zA-Z0-9]+$","type":"string"},
+		"score":{"exclusiveMinimum":1,"type":"number"},
+		"tags":{"items":{"type":"string"},"minItems":1,"type":["array","null"]}
+	  },
+	  "type":"object"
+	}`, s)
+
+	s, err = r.Reflect(My{}, jsonschema.ValidatorTags("check"))
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{"type":"string","format":"uri"}`, s.Properties["custom"])
+
+	s, err = r.Reflect(My{}, jsonschema.ValidatorTags(), jsonschema.ExclusiveBoundsDraft04)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{"maximum":150,"minimum":18,"type":"integer","exclusiveMaximum":true}`, s.Properties["age"])
+	assertjson.EqMarshal(t, `{"minimum":1,"type":"number","exclusiveMinimum":true}`, s.Properties["score"])
+}
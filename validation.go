@@ -0,0 +1,745 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationError describes a value that does not match schema.
+type ValidationError struct {
+	// Path is a JSON Pointer of invalid value, empty for the root value.
+	Path string `json:"path"`
+
+	// Keyword is a name of failed schema keyword, e.g. "minimum".
+	Keyword string `json:"keyword"`
+
+	Message string `json:"message"`
+}
+
+// Error implements error.
+func (e ValidationError) Error() string {
+	return "#" + e.Path + ": " + e.Message
+}
+
+// ValidationErrors is a list of validation errors.
+type ValidationErrors []ValidationError
+
+// Error implements error.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+
+	for _, ve := range e {
+		msgs = append(msgs, ve.Error())
+	}
+
+	return strings.Join(msgs, ", ")
+}
+
+// ValidateJSON validates JSON value against the Schema.
+//
+// Draft-07 keywords are supported except for remote references, local references are resolved
+// against the Schema. Only well-known formats are checked: date-time, date, time, email, hostname,
+// ipv4, ipv6, uri, uuid, regex.
+//
+// ValidationErrors is returned if value is invalid.
+func (s *Schema) ValidateJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("decoding value: %w", err)
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("decoding value: unexpected data after top-level value")
+	}
+
+	return s.validateValue(v)
+}
+
+// ValidateInterface validates Go value against the Schema, value is marshaled to JSON first.
+//
+// See ValidateJSON for details.
+func (s *Schema) ValidateInterface(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding value: %w", err)
+	}
+
+	return s.ValidateJSON(data)
+}
+
+func (s *Schema) validateValue(v interface{}) error {
+	vr := validator{
+		resolve: s.ResolveRef,
+		seen:    map[validationKey]bool{},
+	}
+
+	errs := vr.validate("", s, v)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+type validationKey struct {
+	schema *Schema
+	path   string
+}
+
+type validator struct {
+	resolve func(ref string) (*Schema, bool)
+	seen    map[validationKey]bool
+}
+
+func (vr *validator) validateSchemaOrBool(path string, sb *SchemaOrBool, v interface{}) ValidationErrors {
+	if sb == nil {
+		return nil
+	}
+
+	if sb.TypeBoolean != nil {
+		if *sb.TypeBoolean {
+			return nil
+		}
+
+		return ValidationErrors{{Path: path, Keyword: "false", Message: "value is not allowed"}}
+	}
+
+	return vr.validate(path, sb.TypeObject, v)
+}
+
+func (vr *validator) validate(path string, s *Schema, v interface{}) ValidationErrors {
+	if s == nil {
+		return nil
+	}
+
+	if s.Ref != nil {
+		rs, found := vr.resolve(*s.Ref)
+		if !found {
+			return ValidationErrors{{Path: path, Keyword: "$ref", Message: "unresolved reference " + *s.Ref}}
+		}
+
+		// Other keywords are ignored next to $ref in draft-07.
+		s = rs
+	}
+
+	key := validationKey{schema: s, path: path}
+	if vr.seen[key] {
+		return nil
+	}
+
+	vr.seen[key] = true
+	defer delete(vr.seen, key)
+
+	var errs ValidationErrors
+
+	errs = append(errs, validateType(path, s, v)...)
+	errs = append(errs, validateValues(path, s, v)...)
+
+	switch vv := v.(type) {
+	case json.Number:
+		errs = append(errs, validateNumber(path, s, vv)...)
+	case string:
+		errs = append(errs, validateString(path, s, vv)...)
+	case []interface{}:
+		errs = append(errs, vr.validateArray(path, s, vv)...)
+	case map[string]interface{}:
+		errs = append(errs, vr.validateObject(path, s, vv)...)
+	}
+
+	errs = append(errs, vr.validateCompositions(path, s, v)...)
+
+	return errs
+}
+
+func (vr *validator) valid(path string, sb *SchemaOrBool, v interface{}) bool {
+	return len(vr.validateSchemaOrBool(path, sb, v)) == 0
+}
+
+func jsonType(v interface{}) SimpleType {
+	switch vv := v.(type) {
+	case nil:
+		return Null
+	case bool:
+		return Boolean
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(string(vv)); ok && r.IsInt() {
+			return Integer
+		}
+
+		return Number
+	case string:
+		return String
+	case []interface{}:
+		return Array
+	default:
+		return Object
+	}
+}
+
+func validateType(path string, s *Schema, v interface{}) ValidationErrors {
+	if s.Type == nil {
+		return nil
+	}
+
+	t := jsonType(v)
+
+	if s.HasType(t) || (t == Integer && s.HasType(Number)) {
+		return nil
+	}
+
+	types := s.simpleTypes()
+	names := make([]string, 0, len(types))
+
+	for _, st := range types {
+		names = append(names, string(st))
+	}
+
+	return ValidationErrors{{
+		Path: path, Keyword: "type",
+		Message: fmt.Sprintf("expected %s, got %s", strings.Join(names, " or "), t),
+	}}
+}
+
+func validateValues(path string, s *Schema, v interface{}) ValidationErrors {
+	var errs ValidationErrors
+
+	if s.Const != nil && !jsonEqual(normalizeJSON(*s.Const), v) {
+		errs = append(errs, ValidationError{Path: path, Keyword: "const", Message: "value does not match const"})
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+
+		for _, ev := range s.Enum {
+			if jsonEqual(normalizeJSON(ev), v) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			errs = append(errs, ValidationError{Path: path, Keyword: "enum", Message: "value is not one of enum values"})
+		}
+	}
+
+	return errs
+}
+
+func validateNumber(path string, s *Schema, n json.Number) ValidationErrors {
+	r, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		return ValidationErrors{{Path: path, Keyword: "type", Message: "invalid number " + string(n)}}
+	}
+
+	var errs ValidationErrors
+
+	check := func(bound *float64, keyword string, fail func(c int) bool, msg string) {
+		if bound == nil {
+			return
+		}
+
+		b, ok := new(big.Rat).SetString(strconv.FormatFloat(*bound, 'g', -1, 64))
+		if ok && fail(r.Cmp(b)) {
+			errs = append(errs, ValidationError{
+				Path: path, Keyword: keyword,
+				Message: fmt.Sprintf("%s %s %v", n, msg, *bound),
+			})
+		}
+	}
+
+	check(s.Minimum, "minimum", func(c int) bool { return c < 0 }, "is less than")
+	check(s.ExclusiveMinimum, "exclusiveMinimum", func(c int) bool { return c <= 0 }, "is not greater than")
+	check(s.Maximum, "maximum", func(c int) bool { return c > 0 }, "is greater than")
+	check(s.ExclusiveMaximum, "exclusiveMaximum", func(c int) bool { return c >= 0 }, "is not less than")
+
+	if s.MultipleOf != nil && *s.MultipleOf > 0 {
+		m, ok := new(big.Rat).SetString(strconv.FormatFloat(*s.MultipleOf, 'g', -1, 64))
+		if ok && !new(big.Rat).Quo(r, m).IsInt() {
+			errs = append(errs, ValidationError{
+				Path: path, Keyword: "multipleOf",
+				Message: fmt.Sprintf("%s is not a multiple of %v", n, *s.MultipleOf),
+			})
+		}
+	}
+
+	return errs
+}
+
+func validateString(path string, s *Schema, str string) ValidationErrors {
+	var errs ValidationErrors
+
+	l := int64(utf8.RuneCountInString(str))
+
+	if l < s.MinLength {
+		errs = append(errs, ValidationError{
+			Path: path, Keyword: "minLength",
+			Message: fmt.Sprintf("length %d is less than %d", l, s.MinLength),
+		})
+	}
+
+	if s.MaxLength != nil && l > *s.MaxLength {
+		errs = append(errs, ValidationError{
+			Path: path, Keyword: "maxLength",
+			Message: fmt.Sprintf("length %d is greater than %d", l, *s.MaxLength),
+		})
+	}
+
+	if s.Pattern != nil {
+		re, err := regexp.Compile(*s.Pattern)
+
+		switch {
+		case err != nil:
+			errs = append(errs, ValidationError{Path: path, Keyword: "pattern", Message: "invalid pattern: " + err.Error()})
+		case !re.MatchString(str):
+			errs = append(errs, ValidationError{
+				Path: path, Keyword: "pattern",
+				Message: fmt.Sprintf("%q does not match pattern %q", str, *s.Pattern),
+			})
+		}
+	}
+
+	if s.Format != nil && !validFormat(*s.Format, str) {
+		errs = append(errs, ValidationError{
+			Path: path, Keyword: "format",
+			Message: fmt.Sprintf("%q is not a valid %s", str, *s.Format),
+		})
+	}
+
+	return errs
+}
+
+var (
+	uuidRegex     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnameRegex = regexp.MustCompile(`^(?i:[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)(\.(?i:[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?))*$`)
+)
+
+func validFormat(format, str string) bool {
+	var err error
+
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, str)
+	case "date":
+		_, err = time.Parse(DateLayout, str)
+	case "time":
+		_, err = time.Parse("15:04:05Z07:00", str)
+	case "email":
+		_, err = mail.ParseAddress(str)
+	case "hostname":
+		return len(str) <= 253 && hostnameRegex.MatchString(str)
+	case "ipv4":
+		ip := net.ParseIP(str)
+
+		return ip != nil && ip.To4() != nil && !strings.Contains(str, ":")
+	case "ipv6":
+		return net.ParseIP(str) != nil && strings.Contains(str, ":")
+	case "uri":
+		var u *url.URL
+
+		u, err = url.Parse(str)
+		if err == nil && !u.IsAbs() {
+			return false
+		}
+	case "uuid":
+		return uuidRegex.MatchString(str)
+	case "regex":
+		_, err = regexp.Compile(str)
+	}
+
+	return err == nil
+}
+
+func (vr *validator) validateArray(path string, s *Schema, items []interface{}) ValidationErrors {
+	var errs ValidationErrors
+
+	l := int64(len(items))
+
+	if l < s.MinItems {
+		errs = append(errs, ValidationError{
+			Path: path, Keyword: "minItems",
+			Message: fmt.Sprintf("%d items is less than %d", l, s.MinItems),
+		})
+	}
+
+	if s.MaxItems != nil && l > *s.MaxItems {
+		errs = append(errs, ValidationError{
+			Path: path, Keyword: "maxItems",
+			Message: fmt.Sprintf("%d items is greater than %d", l, *s.MaxItems),
+		})
+	}
+
+	if s.UniqueItems != nil && *s.UniqueItems {
+	unique:
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if jsonEqual(items[i], items[j]) {
+					errs = append(errs, ValidationError{
+						Path: path, Keyword: "uniqueItems",
+						Message: fmt.Sprintf("items %d and %d are equal", i, j),
+					})
+
+					break unique
+				}
+			}
+		}
+	}
+
+	if s.Items != nil {
+		for i, item := range items {
+			ip := path + "/" + strconv.Itoa(i)
+
+			switch {
+			case s.Items.SchemaOrBool != nil:
+				errs = append(errs, vr.validateSchemaOrBool(ip, s.Items.SchemaOrBool, item)...)
+			case i < len(s.Items.SchemaArray):
+				errs = append(errs, vr.validateSchemaOrBool(ip, &s.Items.SchemaArray[i], item)...)
+			default:
+				errs = append(errs, vr.validateSchemaOrBool(ip, s.AdditionalItems, item)...)
+			}
+		}
+	}
+
+	if s.Contains != nil {
+		errs = append(errs, vr.validateContains(path, s, items)...)
+	}
+
+	return errs
+}
+
+// validateContains checks that at least minContains (default 1) items match contains schema.
+func (vr *validator) validateContains(path string, s *Schema, items []interface{}) ValidationErrors {
+	minContains := int64(1)
+	if s.MinContains != nil {
+		minContains = *s.MinContains
+	}
+
+	found := int64(0)
+
+	for i, item := range items {
+		if found >= minContains {
+			return nil
+		}
+
+		if vr.valid(path+"/"+strconv.Itoa(i), s.Contains, item) {
+			found++
+		}
+	}
+
+	switch {
+	case found >= minContains:
+		return nil
+	case s.MinContains == nil:
+		return ValidationErrors{{Path: path, Keyword: "contains", Message: "no items match contains schema"}}
+	default:
+		return ValidationErrors{{
+			Path: path, Keyword: "minContains",
+			Message: fmt.Sprintf("%d items match contains schema, less than %d", found, minContains),
+		}}
+	}
+}
+
+func (vr *validator) validateObject(path string, s *Schema, obj map[string]interface{}) ValidationErrors {
+	var errs ValidationErrors
+
+	l := int64(len(obj))
+
+	if l < s.MinProperties {
+		errs = append(errs, ValidationError{
+			Path: path, Keyword: "minProperties",
+			Message: fmt.Sprintf("%d properties is less than %d", l, s.MinProperties),
+		})
+	}
+
+	if s.MaxProperties != nil && l > *s.MaxProperties {
+		errs = append(errs, ValidationError{
+			Path: path, Keyword: "maxProperties",
+			Message: fmt.Sprintf("%d properties is greater than %d", l, *s.MaxProperties),
+		})
+	}
+
+	for _, name := range s.Required {
+		if _, found := obj[name]; !found {
+			errs = append(errs, ValidationError{
+				Path: path, Keyword: "required",
+				Message: fmt.Sprintf("missing required property %q", name),
+			})
+		}
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		errs = append(errs, vr.validateProperty(path, s, name, obj[name])...)
+	}
+
+	for _, name := range names {
+		for _, r := range s.DependentRequired[name] {
+			if _, found := obj[r]; !found {
+				errs = append(errs, ValidationError{
+					Path: path, Keyword: "dependentRequired",
+					Message: fmt.Sprintf("property %q requires property %q", name, r),
+				})
+			}
+		}
+
+		dep, found := s.Dependencies[name]
+		if !found {
+			continue
+		}
+
+		for _, r := range dep.StringArray {
+			if _, found := obj[r]; !found {
+				errs = append(errs, ValidationError{
+					Path: path, Keyword: "dependencies",
+					Message: fmt.Sprintf("property %q requires property %q", name, r),
+				})
+			}
+		}
+
+		errs = append(errs, vr.validateSchemaOrBool(path, dep.SchemaOrBool, obj)...)
+	}
+
+	return errs
+}
+
+func (vr *validator) validateProperty(path string, s *Schema, name string, v interface{}) ValidationErrors {
+	var errs ValidationErrors
+
+	pp := path + "/" + escapePointer(name)
+
+	if s.PropertyNames != nil && !vr.valid(pp, s.PropertyNames, name) {
+		errs = append(errs, ValidationError{
+			Path: path, Keyword: "propertyNames",
+			Message: fmt.Sprintf("property name %q does not match propertyNames schema", name),
+		})
+	}
+
+	matched := false
+
+	if ps, found := s.Properties[name]; found {
+		matched = true
+
+		errs = append(errs, vr.validateSchemaOrBool(pp, &ps, v)...)
+	}
+
+	for pattern, ps := range s.PatternProperties {
+		re, err := regexp.Compile(pattern)
+		if err != nil || !re.MatchString(name) {
+			continue
+		}
+
+		matched = true
+		ps := ps
+
+		errs = append(errs, vr.validateSchemaOrBool(pp, &ps, v)...)
+	}
+
+	if !matched && s.AdditionalProperties != nil {
+		if s.AdditionalProperties.TypeBoolean != nil && !*s.AdditionalProperties.TypeBoolean {
+			errs = append(errs, ValidationError{
+				Path: path, Keyword: "additionalProperties",
+				Message: fmt.Sprintf("additional property %q is not allowed", name),
+			})
+		} else {
+			errs = append(errs, vr.validateSchemaOrBool(pp, s.AdditionalProperties, v)...)
+		}
+	}
+
+	return errs
+}
+
+func (vr *validator) validateCompositions(path string, s *Schema, v interface{}) ValidationErrors {
+	var errs ValidationErrors
+
+	for i := range s.AllOf {
+		errs = append(errs, vr.validateSchemaOrBool(path, &s.AllOf[i], v)...)
+	}
+
+	if len(s.AnyOf) > 0 {
+		found := false
+
+		for i := range s.AnyOf {
+			if vr.valid(path, &s.AnyOf[i], v) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			errs = append(errs, ValidationError{Path: path, Keyword: "anyOf", Message: "value does not match any schema of anyOf"})
+		}
+	}
+
+	if len(s.OneOf) > 0 {
+		matches := 0
+
+		for i := range s.OneOf {
+			if vr.valid(path, &s.OneOf[i], v) {
+				matches++
+			}
+		}
+
+		if matches != 1 {
+			errs = append(errs, ValidationError{
+				Path: path, Keyword: "oneOf",
+				Message: fmt.Sprintf("value matches %d schemas of oneOf instead of 1", matches),
+			})
+		}
+	}
+
+	if s.Not != nil && vr.valid(path, s.Not, v) {
+		errs = append(errs, ValidationError{Path: path, Keyword: "not", Message: "value must not match schema"})
+	}
+
+	if s.If != nil {
+		if vr.valid(path, s.If, v) {
+			errs = append(errs, vr.validateSchemaOrBool(path, s.Then, v)...)
+		} else {
+			errs = append(errs, vr.validateSchemaOrBool(path, s.Else, v)...)
+		}
+	}
+
+	return errs
+}
+
+// normalizeJSON converts Go value into a value decoded from JSON with numbers as json.Number.
+func normalizeJSON(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var res interface{}
+
+	if err := dec.Decode(&res); err != nil {
+		return v
+	}
+
+	return res
+}
+
+// jsonEqual compares values decoded from JSON.
+func jsonEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+
+		ar, aok := new(big.Rat).SetString(string(av))
+		br, bok := new(big.Rat).SetString(string(bv))
+
+		return aok && bok && ar.Cmp(br) == 0
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+
+		return true
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for k, v := range av {
+			bvv, found := bv[k]
+			if !found || !jsonEqual(v, bvv) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return a == b
+	}
+}
+
+// VerifyExamples enables validation of `default` and `examples` values of properties against property schemas.
+//
+// Reflection fails with an error that lists invalid values with paths of their struct fields.
+func VerifyExamples(rc *ReflectContext) {
+	rc.VerifyExamples = true
+}
+
+type valueCheck struct {
+	path   string
+	schema Schema
+}
+
+func (rc *ReflectContext) verifyValues(root *Schema) error {
+	resolve := func(ref string) (*Schema, bool) {
+		if ref == "#" {
+			return root, true
+		}
+
+		for ts, r := range rc.definitionRefs {
+			if r.Path+r.Name == ref {
+				return rc.definitions[ts], true
+			}
+		}
+
+		return nil, false
+	}
+
+	var msgs []string
+
+	for _, vc := range rc.valueChecks {
+		check := func(name string, v interface{}) {
+			vr := validator{resolve: resolve, seen: map[validationKey]bool{}}
+
+			if errs := vr.validate("", &vc.schema, normalizeJSON(v)); len(errs) > 0 {
+				msgs = append(msgs, fmt.Sprintf("%s: invalid %s: %s", vc.path, name, errs.Error()))
+			}
+		}
+
+		if vc.schema.Default != nil {
+			check("default", *vc.schema.Default)
+		}
+
+		for i, ex := range vc.schema.Examples {
+			check(fmt.Sprintf("examples[%d]", i), ex)
+		}
+	}
+
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "; "))
+	}
+
+	return nil
+}
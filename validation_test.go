@@ -0,0 +1,138 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/jsonschema-go"
+)
+
+func TestSchema_ValidateInterface(t *testing.T) {
+	type Item struct {
+		SKU   string  `json:"sku" pattern:"^[A-Z]+$" required:"true"`
+		Price float64 `json:"price" minimum:"0" exclusiveMaximum:"100" multipleOf:"0.01"`
+	}
+
+	type Order struct {
+		ID     string   `json:"id" format:"uuid" required:"true"`
+		Status string   `json:"status" enum:"new,paid"`
+		Items  []Item   `json:"items" minItems:"1"`
+		Tags   []string `json:"tags" uniqueItems:"true" maxItems:"2"`
+		Parent *Order   `json:"parent,omitempty"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	s, err := r.Reflect(Order{})
+	require.NoError(t, err)
+
+	require.NoError(t, s.ValidateInterface(Order{
+		ID:     "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		Status: "new",
+		Items:  []Item{{SKU: "ABC", Price: 9.99}},
+		Tags:   []string{"a", "b"},
+		Parent: &Order{ID: "6ba7b811-9dad-11d1-80b4-00c04fd430c8", Status: "paid", Items: []Item{{SKU: "X"}}},
+	}))
+
+	err = s.ValidateInterface(Order{
+		ID:     "123",
+		Status: "shipped",
+		Items:  []Item{{SKU: "abc", Price: 100}, {Price: 1.001}},
+		Tags:   []string{"a", "a", "b"},
+		Parent: &Order{ID: "6ba7b811-9dad-11d1-80b4-00c04fd430c8", Status: "paid"},
+	})
+	require.Error(t, err)
+
+	var ve jsonschema.ValidationErrors
+
+	require.ErrorAs(t, err, &ve)
+
+	var keywords []string
+	for _, e := range ve {
+		keywords = append(keywords, e.Path+" "+e.Keyword)
+	}
+
+	assert.Equal(t, []string{
+		"/id format",
+		"/items/0/price exclusiveMaximum",
+		"/items/0/sku pattern",
+		"/items/1/price multipleOf",
+		"/items/1/sku pattern",
+		"/status enum",
+		"/tags maxItems",
+		"/tags uniqueItems",
+	}, keywords)
+	assert.Equal(t, `#/id: "123" is not a valid uuid`, ve[0].Error())
+}
+
+func TestSchema_ValidateJSON(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+	  "type":"object","required":["a"],"additionalProperties":false,
+	  "properties":{
+		"a":{"type":"integer","minimum":1},
+		"b":{"oneOf":[{"type":"string"},{"type":"number"}]},
+		"c":{"if":{"const":"x"},"then":{"maxLength":0},"else":{"minLength":2}},
+		"d":{"not":{"type":"null"}}
+	  },
+	  "patternProperties":{"^x-":{"type":"boolean"}}
+	}`), &s))
+
+	for _, tc := range []struct {
+		value string
+		err   string
+	}{
+		{`{"a":1,"b":"s","d":0,"x-foo":true}`, ""},
+		{`{"a":1.0}`, ""},
+		{`{"a":0}`, "#/a: 0 is less than 1"},
+		{`{"a":1.5}`, "#/a: expected integer, got number"},
+		{`{}`, `#: missing required property "a"`},
+		{`{"a":1,"e":1}`, `#: additional property "e" is not allowed`},
+		{`{"a":1,"x-e":1}`, `#/x-e: expected boolean, got integer`},
+		{`{"a":1,"b":true}`, `#/b: value matches 0 schemas of oneOf instead of 1`},
+		{`{"a":1,"c":"x"}`, `#/c: length 1 is greater than 0`},
+		{`{"a":1,"c":"y"}`, `#/c: length 1 is less than 2`},
+		{`{"a":1,"d":null}`, `#/d: value must not match schema`},
+		{`[]`, `#: expected object, got array`},
+	} {
+		err := s.ValidateJSON([]byte(tc.value))
+		if tc.err == "" {
+			assert.NoError(t, err, tc.value)
+		} else {
+			assert.EqualError(t, err, tc.err, tc.value)
+		}
+	}
+
+	assert.EqualError(t, s.ValidateJSON([]byte(`{`)), "decoding value: unexpected EOF")
+	assert.EqualError(t, s.ValidateJSON([]byte(`{"a":3} x`)), "decoding value: unexpected data after top-level value")
+	assert.EqualError(t, s.ValidateJSON([]byte(`{"a":3} {}`)), "decoding value: unexpected data after top-level value")
+	assert.NoError(t, s.ValidateJSON([]byte("{\"a\":3}\n")))
+}
+
+func TestVerifyExamples(t *testing.T) {
+	type Status string
+
+	type Item struct {
+		Name string `json:"name" minLength:"3"`
+	}
+
+	type Order struct {
+		Count  int    `json:"count" minimum:"1" default:"0" example:"5"`
+		Email  string `json:"email" format:"email" examples:"[\"a@b.c\",\"foo\"]"`
+		Status Status `json:"status" enum:"new,paid" default:"new"`
+		Item   Item   `json:"item" default:"{\"name\":\"ab\"}"`
+	}
+
+	r := jsonschema.Reflector{}
+
+	_, err := r.Reflect(Order{}, jsonschema.VerifyExamples)
+	assert.EqualError(t, err, "Count: invalid default: #: 0 is less than 1; "+
+		`Email: invalid examples[1]: #: "foo" is not a valid email; `+
+		`Item: invalid default: #/name: length 2 is less than 3`)
+
+	_, err = r.Reflect(Order{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,200 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML implements yaml.Marshaler, properties keep the order of JSON encoding.
+func (s Schema) MarshalYAML() (interface{}, error) {
+	return marshalYAML(s)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (s *Schema) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAML(node, s)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (s SchemaOrBool) MarshalYAML() (interface{}, error) {
+	return marshalYAML(s)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (s *SchemaOrBool) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAML(node, s)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (i Items) MarshalYAML() (interface{}, error) {
+	return marshalYAML(i)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Items) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAML(node, i)
+}
+
+// marshalYAML converts JSON encoding of a value into YAML node tree.
+//
+// JSON is a subset of YAML, so parsed node tree keeps original key order and number literals.
+func marshalYAML(v json.Marshaler) (*yaml.Node, error) {
+	data, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to convert JSON to YAML: %w", err)
+	}
+
+	if len(doc.Content) != 1 {
+		return nil, errors.New("unexpected YAML document")
+	}
+
+	node := doc.Content[0]
+	resetYAMLStyle(node)
+
+	return node, nil
+}
+
+// resetYAMLStyle replaces flow and quoted styles inherited from JSON with default YAML styles.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, n := range node.Content {
+		resetYAMLStyle(n)
+	}
+}
+
+// unmarshalYAML converts YAML node tree into JSON and decodes it into a value.
+func unmarshalYAML(node *yaml.Node, v json.Unmarshaler) error {
+	buf := bytes.NewBuffer(nil)
+
+	if err := writeYAMLAsJSON(buf, node); err != nil {
+		return err
+	}
+
+	return v.UnmarshalJSON(buf.Bytes())
+}
+
+func writeYAMLAsJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+
+			return nil
+		}
+
+		return writeYAMLAsJSON(buf, node.Content[0])
+	case yaml.AliasNode:
+		return writeYAMLAsJSON(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if key.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: unsupported mapping key", key.Line)
+			}
+
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			k, err := json.Marshal(key.Value)
+			if err != nil {
+				return err
+			}
+
+			buf.Write(k)
+			buf.WriteByte(':')
+
+			if err := writeYAMLAsJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+
+		for i, n := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeYAMLAsJSON(buf, n); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+	case yaml.ScalarNode:
+		return writeYAMLScalarAsJSON(buf, node)
+	default:
+		return fmt.Errorf("line %d: unexpected YAML node kind %d", node.Line, node.Kind)
+	}
+
+	return nil
+}
+
+func writeYAMLScalarAsJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.ShortTag() {
+	case "!!null":
+		buf.WriteString("null")
+	case "!!bool":
+		var b bool
+
+		if err := node.Decode(&b); err != nil {
+			return err
+		}
+
+		buf.WriteString(strconv.FormatBool(b))
+	case "!!int", "!!float":
+		// Number literal is kept as is when it is valid in JSON to avoid precision loss.
+		if isJSONNumber(node.Value) {
+			buf.WriteString(node.Value)
+
+			return nil
+		}
+
+		var f float64
+
+		if err := node.Decode(&f); err != nil {
+			return err
+		}
+
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return fmt.Errorf("line %d: %s can not be represented in JSON", node.Line, node.Value)
+		}
+
+		buf.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	default:
+		s, err := json.Marshal(node.Value)
+		if err != nil {
+			return err
+		}
+
+		buf.Write(s)
+	}
+
+	return nil
+}
+
+func isJSONNumber(s string) bool {
+	if s == "" || (s[0] != '-' && (s[0] < '0' || s[0] > '9')) {
+		return false
+	}
+
+	return json.Valid([]byte(s))
+}
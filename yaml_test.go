@@ -0,0 +1,116 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/jsonschema-go"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSchema_MarshalYAML(t *testing.T) {
+	var s jsonschema.Schema
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+		"type":"object","title":"Order",
+		"properties":{
+			"id":{"type":"integer","minimum":1,"maximum":1e+30},
+			"price":{"type":"number","multipleOf":0.01,"default":12.50},
+			"code":{"type":"string","enum":["123","yes","null"]},
+			"tags":{"type":"array","items":{"type":"string"}},
+			"meta":{"type":"object","additionalProperties":false}
+		},
+		"x-order":2
+	}`)))
+
+	data, err := yaml.Marshal(s)
+	require.NoError(t, err)
+
+	assert.Equal(t, `title: Order
+properties:
+    code:
+        enum:
+            - "123"
+            - yes
+            - "null"
+        type: string
+    id:
+        maximum: 1e+30
+        minimum: 1
+        type: integer
+    meta:
+        additionalProperties: false
+        type: object
+    price:
+        default: 12.5
+        multipleOf: 0.01
+        type: number
+    tags:
+        items:
+            type: string
+        type: array
+type: object
+x-order: 2
+`, string(data))
+
+	var s2 jsonschema.Schema
+
+	require.NoError(t, yaml.Unmarshal(data, &s2))
+
+	j1, err := s.MarshalJSON()
+	require.NoError(t, err)
+
+	j2, err := s2.MarshalJSON()
+	require.NoError(t, err)
+
+	assertjson.Equal(t, j1, j2)
+}
+
+func TestSchema_UnmarshalYAML(t *testing.T) {
+	type config struct {
+		Schema jsonschema.Schema        `yaml:"schema"`
+		Items  jsonschema.Items         `yaml:"items"`
+		Extra  *jsonschema.SchemaOrBool `yaml:"extra"`
+	}
+
+	var c config
+
+	require.NoError(t, yaml.Unmarshal([]byte(`
+base: &base
+  type: string
+  minLength: 0x10
+schema:
+  type: object
+  properties:
+    name: *base
+    created:
+      type: string
+      default: 2020-01-01
+    ratio:
+      type: number
+      maximum: 1.5
+items:
+  - type: integer
+  - type: boolean
+extra: false
+`), &c))
+
+	assertjson.EqualMarshal(t, []byte(`{
+	  "type":"object",
+	  "properties":{
+		"name":{"minLength":16,"type":"string"},
+		"created":{"default":"2020-01-01","type":"string"},
+		"ratio":{"maximum":1.5,"type":"number"}
+	  }
+	}`), c.Schema)
+
+	assertjson.EqualMarshal(t, []byte(`[{"type":"integer"},{"type":"boolean"}]`), c.Items)
+	require.NotNil(t, c.Extra)
+	require.NotNil(t, c.Extra.TypeBoolean)
+	assert.False(t, *c.Extra.TypeBoolean)
+
+	err := yaml.Unmarshal([]byte("minimum: .inf\n"), &c.Schema)
+	assert.EqualError(t, err, "line 1: .inf can not be represented in JSON")
+}